@@ -10,12 +10,13 @@ import (
 	config "DiscordBotAgent/internal/core/config_env"
 	"DiscordBotAgent/internal/core/config_manager"
 	"DiscordBotAgent/internal/core/eventbus"
+	"DiscordBotAgent/internal/core/featureflags"
 	"DiscordBotAgent/internal/core/module_manager"
+	"DiscordBotAgent/internal/core/startup"
 	"DiscordBotAgent/internal/core/zap_logger"
 	"DiscordBotAgent/internal/modules/template"
 	"DiscordBotAgent/internal/modules/template/buttons"
 	"DiscordBotAgent/internal/modules/template/commands"
-	"DiscordBotAgent/internal/modules/template2"
 
 	"go.uber.org/zap"
 )
@@ -25,6 +26,7 @@ type App struct {
 	log            *zap_logger.Logger
 	eb             *eventbus.EventBus
 	configMgr      *config_manager.Manager
+	flags          *featureflags.Service
 	moduleMgr      *module_manager.Manager
 	interactionMgr *client.Manager
 	client         *client.Client
@@ -43,20 +45,41 @@ func New() (*App, error) {
 	if err := apierror.Init(logger.Logger); err != nil {
 		return nil, fmt.Errorf("api errors init: %w", err)
 	}
-	configMgr, err := config_manager.New(logger, "config_df", "config_mrg")
+	secrets, err := startup.NewSecretStore()
+	if err != nil {
+		return nil, fmt.Errorf("secret store: %w", err)
+	}
+	configOpts := []config_manager.ManagerOption{
+		config_manager.WithPollInterval(cfg.ConfigPollInterval),
+		config_manager.WithMigrationWriteBack(cfg.ConfigWriteBack),
+		config_manager.WithBatchReload(cfg.ConfigBatchWindow),
+		config_manager.WithSecrets(secrets),
+	}
+	source, err := config_manager.ParseSource(cfg.ConfigSource)
+	if err != nil {
+		return nil, fmt.Errorf("config source: %w", err)
+	}
+	if source != nil {
+		configOpts = append(configOpts, config_manager.WithSource(source, cfg.ConfigSourceInterval))
+	}
+	configMgr, err := config_manager.New(logger, "config_df", "config_mrg", configOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("config manager: %w", err)
 	}
+	flags, err := featureflags.New(logger, configMgr)
+	if err != nil {
+		logger.Error("feature flag configuration rejected, all flags are off", zap.Error(err))
+	}
 	eb := eventbus.New(logger)
-	moduleMgr := module_manager.New(logger, configMgr)
-	tmplService := template.NewService(logger)
-	templateMod := template.New(logger, eb, moduleMgr)
-	if err := moduleMgr.Register(templateMod); err != nil {
-		return nil, fmt.Errorf("template module: %w", err)
+	var moduleOpts []module_manager.ManagerOption
+	if cfg.ModuleReflectionScan {
+		moduleOpts = append(moduleOpts, module_manager.WithReflectionScan())
 	}
-	template2Mod := template2.New(logger, eb, moduleMgr, templateMod)
-	if err := moduleMgr.Register(template2Mod); err != nil {
-		return nil, fmt.Errorf("template2 module: %w", err)
+	moduleMgr := module_manager.New(logger, configMgr, moduleOpts...)
+	tmplService := template.NewService(logger)
+	templateMod, err := registerModules(logger, eb, moduleMgr, flags)
+	if err != nil {
+		return nil, err
 	}
 	discordClient, err := client.New(cfg, logger, eb)
 	if err != nil {
@@ -81,12 +104,13 @@ func New() (*App, error) {
 	deleteBtn := &buttons.DeleteButton{}
 	interactionMgr.RegisterButton(deleteBtn, templateMod.Name())
 	eb.Subscribe(eventbus.InteractionCreate, interactionMgr.HandleInteraction)
-	apiServer := api.New(logger, moduleMgr)
+	apiServer := api.New(logger, moduleMgr, configMgr, flags, cfg.APIKeys)
 	return &App{
 		cfg:            cfg,
 		log:            logger,
 		eb:             eb,
 		configMgr:      configMgr,
+		flags:          flags,
 		moduleMgr:      moduleMgr,
 		interactionMgr: interactionMgr,
 		client:         discordClient,
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	_ "DiscordBotAgent/docs"
 )
@@ -12,6 +13,23 @@ import (
 // @host localhost:8001
 // @BasePath /
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "secret":
+			if err := runSecret(os.Args[2:]); err != nil {
+				log.Fatalf("secret: %v", err)
+			}
+			return
+		case "reference":
+			if err := runReference(os.Args[2:]); err != nil {
+				log.Fatalf("reference: %v", err)
+			}
+			return
+		case "validate":
+			os.Exit(runValidate(os.Args[2:]))
+		}
+	}
+
 	application, err := New()
 	if err != nil {
 		log.Fatalf("initialization failed: %v", err)
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/eventbus"
+	"DiscordBotAgent/internal/core/featureflags"
+	"DiscordBotAgent/internal/core/module_manager"
+	"DiscordBotAgent/internal/core/startup"
+	"DiscordBotAgent/internal/core/zap_logger"
+	"DiscordBotAgent/internal/modules/template"
+	"DiscordBotAgent/internal/modules/template2"
+)
+
+func registerModules(
+	logger *zap_logger.Logger,
+	eb *eventbus.EventBus,
+	moduleMgr *module_manager.Manager,
+	flags *featureflags.Service,
+) (*template.Module, error) {
+	var errs []error
+
+	templateMod := template.New(logger, eb, moduleMgr)
+	if err := moduleMgr.Register(templateMod); err != nil {
+		errs = append(errs, fmt.Errorf("template module: %w", err))
+	}
+	template2Mod := template2.New(logger, eb, moduleMgr, flags, templateMod)
+	if err := moduleMgr.Register(template2Mod); err != nil {
+		errs = append(errs, fmt.Errorf("template2 module: %w", err))
+	}
+
+	return templateMod, errors.Join(errs...)
+}
+
+type headless struct {
+	configMgr *config_manager.Manager
+	moduleMgr *module_manager.Manager
+	flagsErr  error
+}
+
+// newHeadless builds the module registry on a read-only config manager without connecting to Discord.
+func newHeadless(
+	pathDefault, pathMerge string,
+) (*headless, error) {
+	logger := zap_logger.NewNop()
+
+	secrets, err := startup.NewSecretStore()
+	if err != nil {
+		secrets = startup.NewEnvSecretStore()
+	}
+
+	configMgr, err := config_manager.New(
+		logger,
+		pathDefault,
+		pathMerge,
+		config_manager.WithReadOnly(),
+		config_manager.WithSecrets(secrets),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("config manager: %w", err)
+	}
+
+	h := &headless{
+		configMgr: configMgr,
+		moduleMgr: module_manager.New(logger, configMgr),
+	}
+	flags, flagsErr := featureflags.New(logger, configMgr)
+	h.flagsErr = flagsErr
+	// Registration errors are recorded in the module states and reported by the caller.
+	_, _ = registerModules(logger, eventbus.New(logger), h.moduleMgr, flags)
+
+	return h, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"DiscordBotAgent/internal/core/config_manager"
+)
+
+func runReference(args []string) error {
+	fs := flag.NewFlagSet("reference", flag.ContinueOnError)
+	pathDefault := fs.String("defaults", "config_df", "directory with base configuration files")
+	pathMerge := fs.String("merge", "config_mrg", "directory with MERGE overlays")
+	out := fs.String("out", "", "output directory (default <defaults>/"+config_manager.DirReference+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	h, err := newHeadless(*pathDefault, *pathMerge)
+	if err != nil {
+		return err
+	}
+	configMgr := h.configMgr
+	defer func() {
+		_ = configMgr.Close()
+	}()
+
+	dir := *out
+	if dir == "" {
+		dir = filepath.Join(*pathDefault, config_manager.DirReference)
+	}
+
+	written, err := configMgr.WriteReferences(dir)
+	for _, path := range written {
+		fmt.Println(path)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"DiscordBotAgent/internal/core/startup"
+)
+
+func runSecret(args []string) error {
+	if len(args) != 2 || (args[0] != "set" && args[0] != "delete") {
+		return fmt.Errorf("usage: secret set|delete <NAME>")
+	}
+
+	store, err := startup.NewSecretStore()
+	if err != nil {
+		return fmt.Errorf("secret store: %w", err)
+	}
+
+	name := args[1]
+	if args[0] == "delete" {
+		return store.Delete(name)
+	}
+
+	fmt.Printf("Enter value for %s: ", name)
+	value, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && value == "" {
+		return fmt.Errorf("read secret: %w", err)
+	}
+	value = strings.TrimRight(value, "\r\n")
+	if value == "" {
+		return fmt.Errorf("secret value is empty")
+	}
+
+	if err := store.Save(name, value); err != nil {
+		return err
+	}
+	fmt.Printf("Secret %s saved.\n", name)
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/module_manager"
+	"DiscordBotAgent/internal/core/startup"
+)
+
+const (
+	validateStatusValid   = "valid"
+	validateStatusInvalid = "invalid"
+	validateStatusMissing = "missing"
+)
+
+type validateReport struct {
+	Valid       bool             `json:"valid"`
+	Configs     []validateConfig `json:"configs"`
+	UnusedFiles []string         `json:"unused_files,omitempty"`
+}
+
+type validateConfig struct {
+	Module string                      `json:"module"`
+	Config string                      `json:"config"`
+	Status string                      `json:"status"`
+	Error  string                      `json:"error,omitempty"`
+	Errors []config_manager.FieldError `json:"errors,omitempty"`
+	Guilds []config_manager.GuildCheck `json:"guilds,omitempty"`
+}
+
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	pathDefault := fs.String("defaults", "config_df", "directory with base configuration files")
+	pathMerge := fs.String("merge", "config_mrg", "directory with MERGE overlays")
+	allowMissing := fs.Bool("allow-missing", false, "do not fail on missing configuration files")
+	strict := fs.Bool("strict", false, "also fail on unused files")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	startup.LoadEnvFiles()
+
+	report, err := validateConfigs(*pathDefault, *pathMerge, *allowMissing, *strict)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		return 2
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		return 2
+	}
+
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
+
+func validateConfigs(
+	pathDefault, pathMerge string,
+	allowMissing, strict bool,
+) (*validateReport, error) {
+	h, err := newHeadless(pathDefault, pathMerge)
+	if err != nil {
+		return nil, err
+	}
+	configMgr := h.configMgr
+	defer func() {
+		_ = configMgr.Close()
+	}()
+
+	report := &validateReport{Valid: true}
+
+	modules := h.moduleMgr.GetAllModules()
+	sort.Slice(
+		modules, func(i, j int) bool {
+			return modules[i].Name < modules[j].Name
+		},
+	)
+
+	for _, info := range modules {
+		if info.ConfigKey == "" {
+			continue
+		}
+
+		result := validateConfig{
+			Module: info.Name,
+			Config: info.ConfigKey,
+			Status: validateStatusValid,
+		}
+
+		_, registered := configMgr.Info(info.ConfigKey)
+		switch {
+		case registered:
+			result.Guilds = configMgr.CheckGuilds(info.ConfigKey)
+			for _, g := range result.Guilds {
+				if !g.Valid {
+					result.Status = validateStatusInvalid
+				}
+			}
+		case info.Status == module_manager.StatusError:
+			result.Status = validateStatusInvalid
+			result.Error = info.ErrorMessage
+			result.Errors = info.ConfigErrors
+		default:
+			result.Status = validateStatusMissing
+			result.Error = info.ErrorMessage
+		}
+
+		if result.Status == validateStatusInvalid || (!allowMissing && result.Status == validateStatusMissing) {
+			report.Valid = false
+		}
+		report.Configs = append(report.Configs, result)
+	}
+
+	flags := validateConfig{
+		Module: "featureflags",
+		Config: config_manager.Contract.System.FeatureFlags,
+		Status: validateStatusValid,
+	}
+	switch {
+	case errors.Is(h.flagsErr, config_manager.ErrConfigMissing):
+		flags.Status = validateStatusMissing
+		flags.Error = h.flagsErr.Error()
+	case h.flagsErr != nil:
+		flags.Status = validateStatusInvalid
+		flags.Error = h.flagsErr.Error()
+		var vErr *config_manager.ValidationError
+		if errors.As(h.flagsErr, &vErr) {
+			flags.Errors = vErr.Fields
+		}
+	}
+	if flags.Status == validateStatusInvalid || (!allowMissing && flags.Status == validateStatusMissing) {
+		report.Valid = false
+	}
+	report.Configs = append(report.Configs, flags)
+
+	for _, f := range configMgr.ScanUsage().Files {
+		if !f.IsUsed {
+			report.UnusedFiles = append(report.UnusedFiles, f.Path)
+		}
+	}
+	if strict && len(report.UnusedFiles) > 0 {
+		report.Valid = false
+	}
+
+	return report, nil
+}
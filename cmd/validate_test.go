@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"DiscordBotAgent/internal/core/config_manager"
+)
+
+func copyBaseConfigs(
+	t *testing.T,
+	dst string,
+) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "config_df", "*"+config_manager.ExtensionYaml))
+	if err != nil {
+		t.Fatalf("glob base configs: %v", err)
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, src := range files {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatalf("read base config: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dst, filepath.Base(src)), content, 0644); err != nil {
+			t.Fatalf("write base config: %v", err)
+		}
+	}
+}
+
+func TestValidateConfigs_BrokenMergeFileFails(t *testing.T) {
+	cases := map[string]struct {
+		files map[string]string
+		rule  string
+	}{
+		"syntax": {
+			files: map[string]string{"MERGE.system.discord.template2.yaml": "max_logs: [5\n"},
+			rule:  "syntax",
+		},
+		"include cycle": {
+			files: map[string]string{
+				"MERGE.system.discord.template2.yaml": "$include: shared/a.yaml\n",
+				"shared/a.yaml":                       "$include: b.yaml\n",
+				"shared/b.yaml":                       "$include: a.yaml\n",
+			},
+			rule: "include",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(
+			name, func(t *testing.T) {
+				dir := t.TempDir()
+				pathDefault := filepath.Join(dir, "config_df")
+				pathMerge := filepath.Join(dir, "config_mrg")
+				copyBaseConfigs(t, pathDefault)
+				for file, content := range tc.files {
+					path := filepath.Join(pathMerge, file)
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatalf("mkdir: %v", err)
+					}
+					if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+						t.Fatalf("write merge file: %v", err)
+					}
+				}
+
+				report, err := validateConfigs(pathDefault, pathMerge, false, false)
+				if err != nil {
+					t.Fatalf("validateConfigs() error: %v", err)
+				}
+				if report.Valid {
+					t.Fatal("expected a broken MERGE file to fail validation")
+				}
+
+				for _, c := range report.Configs {
+					if c.Config != config_manager.Contract.System.Discord.Template2 {
+						continue
+					}
+					if c.Status != validateStatusInvalid || len(c.Errors) != 1 || c.Errors[0].Rule != tc.rule {
+						t.Errorf("unexpected template2 result: %+v", c)
+					}
+					if filepath.Base(c.Errors[0].File) != "MERGE.system.discord.template2.yaml" {
+						t.Errorf("expected the error to point at the MERGE file, got %s", c.Errors[0].File)
+					}
+					return
+				}
+				t.Fatal("template2 missing from report")
+			},
+		)
+	}
+}
+
+func TestValidateConfigs_MissingBaseFileFailsUnlessAllowed(t *testing.T) {
+	dir := t.TempDir()
+	pathDefault := filepath.Join(dir, "config_df")
+	pathMerge := filepath.Join(dir, "config_mrg")
+	copyBaseConfigs(t, pathDefault)
+	if err := os.Remove(filepath.Join(pathDefault, config_manager.Contract.System.Discord.Template2+config_manager.ExtensionYaml)); err != nil {
+		t.Fatalf("remove base config: %v", err)
+	}
+
+	report, err := validateConfigs(pathDefault, pathMerge, false, false)
+	if err != nil {
+		t.Fatalf("validateConfigs() error: %v", err)
+	}
+	if report.Valid {
+		t.Error("a missing base file must fail validation by default")
+	}
+
+	report, err = validateConfigs(pathDefault, pathMerge, true, false)
+	if err != nil {
+		t.Fatalf("validateConfigs() error: %v", err)
+	}
+	if !report.Valid {
+		t.Errorf("a missing base file must pass with allowMissing: %+v", report.Configs)
+	}
+}
@@ -33,6 +33,8 @@ type errorRegistry struct {
 	CONFIG_INVALID     *AppError
 	CONFIG_PARSE_ERROR *AppError
 
+	CONFIG_REVISION_NOT_FOUND *AppError
+
 	DISCORD_NOT_CONNECTED     *AppError
 	DISCORD_GUILD_NOT_FOUND   *AppError
 	DISCORD_CHANNEL_NOT_FOUND *AppError
@@ -57,6 +59,7 @@ var Errors = &errorRegistry{
 	CONFIG_NOT_FOUND:          &AppError{Code: "CONFIG_NOT_FOUND", Status: 404},
 	CONFIG_INVALID:            &AppError{Code: "CONFIG_INVALID", Status: 400},
 	CONFIG_PARSE_ERROR:        &AppError{Code: "CONFIG_PARSE_ERROR", Status: 400},
+	CONFIG_REVISION_NOT_FOUND: &AppError{Code: "CONFIG_REVISION_NOT_FOUND", Status: 404},
 	DISCORD_NOT_CONNECTED:     &AppError{Code: "DISCORD_NOT_CONNECTED", Status: 503},
 	DISCORD_GUILD_NOT_FOUND:   &AppError{Code: "DISCORD_GUILD_NOT_FOUND", Status: 404},
 	DISCORD_CHANNEL_NOT_FOUND: &AppError{Code: "DISCORD_CHANNEL_NOT_FOUND", Status: 404},
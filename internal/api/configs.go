@@ -0,0 +1,330 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"DiscordBotAgent/internal/api/apierror"
+	"DiscordBotAgent/internal/core/config_manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get configurations list
+// @Description Get registered configurations with their reload policy and last rejected revision
+// @Tags configs
+// @Produce json
+// @Success 200 {array} config_manager.ConfigInfo
+// @Router /api/v1/configs [get]
+func (s *Server) handleGetConfigs(c *gin.Context) {
+	c.JSON(http.StatusOK, s.cm.GetAllConfigs())
+}
+
+// @Summary Audit configuration files
+// @Description Report unused and orphaned MERGE files, MERGE keys missing from the config struct, base values equal to module defaults and placeholder-only configs
+// @Tags configs
+// @Produce json
+// @Success 200 {object} config_manager.AuditReport
+// @Router /api/v1/configs/audit [get]
+func (s *Server) handleGetConfigAudit(c *gin.Context) {
+	c.JSON(http.StatusOK, s.cm.Audit())
+}
+
+// @Summary Query configuration change log
+// @Description Get entries of the append-only change log with source, actor, diff, outcome and correlation ID, oldest first
+// @Tags configs
+// @Produce json
+// @Param config query string false "Configuration Name"
+// @Param actor query string false "API key identity"
+// @Param outcome query string false "Outcome (applied, rejected, held)"
+// @Param since query string false "RFC 3339 start time"
+// @Param until query string false "RFC 3339 end time"
+// @Param limit query int false "Maximum number of most recent entries (default 100)"
+// @Success 200 {array} config_manager.AuditEntry
+// @Failure 400 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/audit/log [get]
+func (s *Server) handleGetConfigAuditLog(c *gin.Context) {
+	q := config_manager.AuditQuery{
+		Config:  c.Query("config"),
+		Actor:   c.Query("actor"),
+		Outcome: config_manager.AuditOutcome(c.Query("outcome")),
+	}
+
+	var err error
+	if raw := c.Query("since"); raw != "" {
+		if q.Since, err = time.Parse(time.RFC3339, raw); err != nil {
+			apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("query parameter 'since' must be an RFC 3339 time"))
+			return
+		}
+	}
+	if raw := c.Query("until"); raw != "" {
+		if q.Until, err = time.Parse(time.RFC3339, raw); err != nil {
+			apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("query parameter 'until' must be an RFC 3339 time"))
+			return
+		}
+	}
+	if raw := c.Query("limit"); raw != "" {
+		if q.Limit, err = strconv.Atoi(raw); err != nil || q.Limit < 1 {
+			apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("query parameter 'limit' must be a positive number"))
+			return
+		}
+	}
+
+	entries, err := s.cm.AuditLog(q)
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// @Summary Get configuration detail
+// @Description Get reload state of a registered configuration, including the last rejected revision and its error
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Success 200 {object} config_manager.ConfigInfo
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name} [get]
+func (s *Server) handleGetConfigDetail(c *gin.Context) {
+	info, ok := s.cm.Info(c.Param("name"))
+	if !ok {
+		apierror.Abort(c, apierror.Errors.CONFIG_NOT_FOUND)
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// @Summary Get configuration revisions
+// @Description Get the list of accepted revisions of a configuration (content omitted)
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Success 200 {array} config_manager.Revision
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/revisions [get]
+func (s *Server) handleGetConfigRevisions(c *gin.Context) {
+	revisions, err := s.cm.Revisions(c.Param("name"))
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// @Summary Get configuration revision
+// @Description Get a single revision including the merged configuration content
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Param revision path int true "Revision Number"
+// @Success 200 {object} config_manager.Revision
+// @Failure 400 {object} apierror.ErrorResponse
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/revisions/{revision} [get]
+func (s *Server) handleGetConfigRevision(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("path parameter 'revision' must be a number"))
+		return
+	}
+
+	rev, err := s.cm.Revision(c.Param("name"), number)
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, rev)
+}
+
+// @Summary Restore configuration revision
+// @Description Re-apply a stored revision through the regular validation and update path
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Param revision path int true "Revision Number"
+// @Success 200 {object} config_manager.ConfigInfo
+// @Failure 400 {object} apierror.ErrorResponse
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/revisions/{revision}/restore [post]
+func (s *Server) handleRestoreConfigRevision(c *gin.Context) {
+	name := c.Param("name")
+
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("path parameter 'revision' must be a number"))
+		return
+	}
+
+	if err := s.cm.Rollback(c.Request.Context(), name, number); err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	info, _ := s.cm.Info(name)
+	c.JSON(http.StatusOK, info)
+}
+
+// @Summary Diff configuration revisions
+// @Description Get key-level changes between two revisions
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Param from query int true "Source Revision"
+// @Param to query int true "Target Revision"
+// @Success 200 {array} config_manager.Change
+// @Failure 400 {object} apierror.ErrorResponse
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/diff [get]
+func (s *Server) handleGetConfigDiff(c *gin.Context) {
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("query parameters 'from' and 'to' must be numbers"))
+		return
+	}
+
+	changes, err := s.cm.DiffRevisions(c.Param("name"), from, to)
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	if changes == nil {
+		changes = []config_manager.Change{}
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// @Summary Get configuration JSON Schema
+// @Description Get the JSON Schema generated from the registered config struct, its yaml and validate tags
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Success 200 {object} map[string]any
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/schema [get]
+func (s *Server) handleGetConfigSchema(c *gin.Context) {
+	schema, err := s.cm.Schema(c.Param("name"))
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, schema)
+}
+
+// @Summary Get configuration reference
+// @Description Get a YAML reference of the config with module defaults, field descriptions, examples and validation constraints as comments
+// @Tags configs
+// @Produce application/yaml
+// @Param name path string true "Configuration Name"
+// @Success 200 {string} string
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/reference [get]
+func (s *Server) handleGetConfigReference(c *gin.Context) {
+	content, err := s.cm.Reference(c.Param("name"))
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", content)
+}
+
+// @Summary Get configuration value
+// @Description Get the effective configuration and the content of its MERGE override file
+// @Tags configs
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Success 200 {object} config_manager.ConfigDocument
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/value [get]
+func (s *Server) handleGetConfigValue(c *gin.Context) {
+	doc, err := s.cm.Document(c.Param("name"))
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// @Summary Replace configuration overrides
+// @Description Validate and atomically replace config_mrg/MERGE.<name>.yaml; the watcher applies the change
+// @Tags configs
+// @Accept json
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Param body body map[string]any true "Full MERGE file content"
+// @Success 202 {object} config_manager.ConfigDocument
+// @Failure 400 {object} apierror.ErrorResponse
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/value [put]
+func (s *Server) handleReplaceConfigValue(c *gin.Context) {
+	s.writeConfigValue(c, s.cm.ReplaceMerge)
+}
+
+// @Summary Patch configuration overrides
+// @Description Deep-merge the body into config_mrg/MERGE.<name>.yaml after validation; the watcher applies the change
+// @Tags configs
+// @Accept json
+// @Produce json
+// @Param name path string true "Configuration Name"
+// @Param body body map[string]any true "Partial override"
+// @Success 202 {object} config_manager.ConfigDocument
+// @Failure 400 {object} apierror.ErrorResponse
+// @Failure 404 {object} apierror.ErrorResponse
+// @Router /api/v1/configs/{name}/value [patch]
+func (s *Server) handlePatchConfigValue(c *gin.Context) {
+	s.writeConfigValue(c, s.cm.PatchMerge)
+}
+
+func (s *Server) writeConfigValue(
+	c *gin.Context,
+	write func(
+		ctx context.Context,
+		name string,
+		data map[string]any,
+	) (config_manager.ConfigDocument, error),
+) {
+	var body map[string]any
+	if err := c.ShouldBindJSON(&body); err != nil {
+		apierror.Abort(c, apierror.Errors.INVALID_REQUEST.WithMeta("request body must be a JSON object"))
+		return
+	}
+
+	doc, err := write(c.Request.Context(), c.Param("name"), body)
+	if err != nil {
+		apierror.Abort(c, configError(err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, doc)
+}
+
+func configError(err error) error {
+	var vErr *config_manager.ValidationError
+
+	switch {
+	case errors.Is(err, config_manager.ErrConfigNotFound):
+		return apierror.Errors.CONFIG_NOT_FOUND
+	case errors.Is(err, config_manager.ErrRevisionNotFound):
+		return apierror.Errors.CONFIG_REVISION_NOT_FOUND
+	case errors.Is(err, config_manager.ErrConfigParse) && errors.As(err, &vErr):
+		return apierror.Errors.CONFIG_PARSE_ERROR.WithMeta(vErr.Fields).Wrap(err)
+	case errors.Is(err, config_manager.ErrConfigParse):
+		return apierror.Errors.CONFIG_PARSE_ERROR.WithMeta(err.Error()).Wrap(err)
+	case errors.As(err, &vErr):
+		return apierror.Errors.CONFIG_INVALID.WithMeta(vErr.Fields).Wrap(err)
+	default:
+		return apierror.Errors.INTERNAL_ERROR.Wrap(err)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"DiscordBotAgent/internal/api/apierror"
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/zap_logger"
+)
+
+type auditLogTestConfig struct {
+	Name string `yaml:"name"`
+}
+
+func setupAuditLogServer(t *testing.T) *Server {
+	t.Helper()
+
+	dir := t.TempDir()
+	pathDefault := filepath.Join(dir, "config_df")
+	cm, err := config_manager.New(zap_logger.NewNop(), pathDefault, filepath.Join(dir, "config_mrg"))
+	if err != nil {
+		t.Fatalf("config_manager.New() error: %v", err)
+	}
+	t.Cleanup(func() { _ = cm.Close() })
+
+	if err := os.WriteFile(filepath.Join(pathDefault, "audited.yaml"), []byte("name: audited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Register("audited", auditLogTestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	s := New(zap_logger.NewNop(), nil, cm, nil, nil)
+	s.router.GET("/audit/log", s.handleGetConfigAuditLog)
+	return s
+}
+
+func TestHandleGetConfigAuditLog(t *testing.T) {
+	s := setupAuditLogServer(t)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/audit/log?config=audited&limit=1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var entries []config_manager.AuditEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Config != "audited" || entries[0].Source != config_manager.SourceRegister {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestHandleGetConfigAuditLog_InvalidQuery(t *testing.T) {
+	s := setupAuditLogServer(t)
+
+	for _, query := range []string{
+		"since=yesterday",
+		"until=2026-13-01T00:00:00Z",
+		"limit=abc",
+		"limit=0",
+		"limit=-5",
+	} {
+		t.Run(
+			query, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/audit/log?"+query, nil))
+
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+				}
+				if code := errorCode(t, w); code != apierror.Errors.INVALID_REQUEST.Code {
+					t.Errorf("expected error %s, got %s", apierror.Errors.INVALID_REQUEST.Code, code)
+				}
+			},
+		)
+	}
+}
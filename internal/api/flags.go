@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"DiscordBotAgent/internal/core/featureflags"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get feature flags
+// @Description Get defined and evaluated feature flags with their definition, evaluation counts and most recent evaluations
+// @Tags flags
+// @Produce json
+// @Success 200 {array} featureflags.FlagStatus
+// @Router /api/v1/flags [get]
+func (s *Server) handleGetFlags(c *gin.Context) {
+	c.JSON(http.StatusOK, s.ff.Statuses())
+}
+
+// @Summary Evaluate feature flag
+// @Description Evaluate a flag for a guild, user and roles without recording the result
+// @Tags flags
+// @Produce json
+// @Param name path string true "Flag Name"
+// @Param guild_id query string false "Guild ID"
+// @Param user_id query string false "User ID"
+// @Param role_id query []string false "Role IDs" collectionFormat(multi)
+// @Success 200 {object} featureflags.Evaluation
+// @Router /api/v1/flags/{name}/evaluate [get]
+func (s *Server) handleEvaluateFlag(c *gin.Context) {
+	subject := featureflags.Subject{
+		GuildID: c.Query("guild_id"),
+		UserID:  c.Query("user_id"),
+		RoleIDs: c.QueryArray("role_id"),
+	}
+
+	c.JSON(http.StatusOK, s.ff.Check(c.Param("name"), subject))
+}
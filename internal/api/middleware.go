@@ -1,8 +1,13 @@
 package api
 
 import (
+	"crypto/subtle"
+	"strings"
 	"time"
 
+	"DiscordBotAgent/internal/api/apierror"
+	"DiscordBotAgent/pkg/ctxtrace"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -30,6 +35,9 @@ func (s *Server) loggerMiddleware() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
+		if actor := ctxtrace.ExtractActor(ctx); actor != "" {
+			fields = append(fields, zap.String("actor", actor))
+		}
 
 		if status >= 500 {
 			s.log.WithCtx(ctx).Error("http server error", fields...)
@@ -40,3 +48,37 @@ func (s *Server) loggerMiddleware() gin.HandlerFunc {
 		}
 	}
 }
+
+// apiKeyMiddleware resolves the caller identity from X-API-Key or a Bearer token.
+// Without configured keys the API stays open and changes are recorded without an actor.
+func (s *Server) apiKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(s.keys) == 0 {
+			c.Next()
+			return
+		}
+
+		key := c.GetHeader("X-API-Key")
+		if key == "" {
+			key = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		}
+		if key == "" {
+			apierror.Abort(c, apierror.Errors.UNAUTHORIZED)
+			return
+		}
+
+		identity := ""
+		for known, name := range s.keys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(known)) == 1 {
+				identity = name
+			}
+		}
+		if identity == "" {
+			apierror.Abort(c, apierror.Errors.INVALID_API_KEY)
+			return
+		}
+
+		c.Request = c.Request.WithContext(ctxtrace.WithActor(c.Request.Context(), identity))
+		c.Next()
+	}
+}
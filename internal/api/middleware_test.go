@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"DiscordBotAgent/internal/api/apierror"
+	"DiscordBotAgent/internal/core/zap_logger"
+	"DiscordBotAgent/pkg/ctxtrace"
+
+	"github.com/gin-gonic/gin"
+)
+
+func errorCode(
+	t *testing.T,
+	w *httptest.ResponseRecorder,
+) string {
+	t.Helper()
+
+	var resp apierror.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Errors) != 1 {
+		t.Fatalf("unexpected error response %q: %v", w.Body.String(), err)
+	}
+	return resp.Errors[0].Code
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	s := New(zap_logger.NewNop(), nil, nil, nil, map[string]string{"secret-key": "alice"})
+	s.router.Use(s.apiKeyMiddleware())
+	s.router.GET(
+		"/whoami", func(c *gin.Context) {
+			c.String(http.StatusOK, ctxtrace.ExtractActor(c.Request.Context()))
+		},
+	)
+
+	cases := map[string]struct {
+		header, value string
+		status        int
+		code          string
+	}{
+		"missing key": {status: http.StatusUnauthorized, code: apierror.Errors.UNAUTHORIZED.Code},
+		"wrong key":   {header: "X-API-Key", value: "other", status: http.StatusUnauthorized, code: apierror.Errors.INVALID_API_KEY.Code},
+		"header key":  {header: "X-API-Key", value: "secret-key", status: http.StatusOK},
+		"bearer key":  {header: "Authorization", value: "Bearer secret-key", status: http.StatusOK},
+		"wrong bearer": {
+			header: "Authorization",
+			value:  "Bearer other",
+			status: http.StatusUnauthorized,
+			code:   apierror.Errors.INVALID_API_KEY.Code,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(
+			name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+				if tc.header != "" {
+					req.Header.Set(tc.header, tc.value)
+				}
+				w := httptest.NewRecorder()
+				s.router.ServeHTTP(w, req)
+
+				if w.Code != tc.status {
+					t.Fatalf("expected status %d, got %d: %s", tc.status, w.Code, w.Body.String())
+				}
+				if tc.code != "" {
+					if code := errorCode(t, w); code != tc.code {
+						t.Errorf("expected error %s, got %s", tc.code, code)
+					}
+					return
+				}
+				if w.Body.String() != "alice" {
+					t.Errorf("expected actor alice, got %q", w.Body.String())
+				}
+			},
+		)
+	}
+}
+
+func TestAPIKeyMiddleware_OpenWithoutKeys(t *testing.T) {
+	s := New(zap_logger.NewNop(), nil, nil, nil, nil)
+	s.router.Use(s.apiKeyMiddleware())
+	s.router.GET(
+		"/open", func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		},
+	)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/open", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected the API to stay open without keys, got %d", w.Code)
+	}
+}
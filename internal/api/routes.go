@@ -26,8 +26,27 @@ func (s *Server) registerRoutes(port string) {
 	v1 := s.router.Group("/api/v1")
 	{
 		v1.GET("/health", s.handleHealth)
+
+		v1.Use(s.apiKeyMiddleware())
 		v1.GET("/modules", s.handleGetModules)
 		v1.GET("/modules/detail", s.handleGetModuleDetail)
+
+		v1.GET("/configs", s.handleGetConfigs)
+		v1.GET("/configs/audit", s.handleGetConfigAudit)
+		v1.GET("/configs/audit/log", s.handleGetConfigAuditLog)
+		v1.GET("/configs/:name", s.handleGetConfigDetail)
+		v1.GET("/configs/:name/revisions", s.handleGetConfigRevisions)
+		v1.GET("/configs/:name/revisions/:revision", s.handleGetConfigRevision)
+		v1.POST("/configs/:name/revisions/:revision/restore", s.handleRestoreConfigRevision)
+		v1.GET("/configs/:name/diff", s.handleGetConfigDiff)
+		v1.GET("/configs/:name/schema", s.handleGetConfigSchema)
+		v1.GET("/configs/:name/reference", s.handleGetConfigReference)
+		v1.GET("/configs/:name/value", s.handleGetConfigValue)
+		v1.PUT("/configs/:name/value", s.handleReplaceConfigValue)
+		v1.PATCH("/configs/:name/value", s.handlePatchConfigValue)
+
+		v1.GET("/flags", s.handleGetFlags)
+		v1.GET("/flags/:name/evaluate", s.handleEvaluateFlag)
 	}
 }
 
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/featureflags"
 	"DiscordBotAgent/internal/core/module_manager"
 	"DiscordBotAgent/internal/core/zap_logger"
 
@@ -15,6 +17,9 @@ import (
 type Server struct {
 	log    *zap_logger.Logger
 	mm     *module_manager.Manager
+	cm     *config_manager.Manager
+	ff     *featureflags.Service
+	keys   map[string]string
 	router *gin.Engine
 	srv    *http.Server
 }
@@ -22,6 +27,9 @@ type Server struct {
 func New(
 	log *zap_logger.Logger,
 	mm *module_manager.Manager,
+	cm *config_manager.Manager,
+	ff *featureflags.Service,
+	apiKeys map[string]string,
 ) *Server {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
@@ -29,6 +37,9 @@ func New(
 	s := &Server{
 		log:    log,
 		mm:     mm,
+		cm:     cm,
+		ff:     ff,
+		keys:   apiKeys,
 		router: router,
 	}
 
@@ -3,6 +3,10 @@ package config
 import (
 	"DiscordBotAgent/internal/core/startup"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -11,6 +15,18 @@ type Config struct {
 	AppID    string
 	GuildID  string
 	Port     string
+
+	ConfigPollInterval time.Duration
+	ConfigWriteBack    bool
+	ConfigBatchWindow  time.Duration
+
+	ConfigSource         string
+	ConfigSourceInterval time.Duration
+
+	// APIKeys maps an API key to the identity recorded as actor of config changes.
+	APIKeys map[string]string
+
+	ModuleReflectionScan bool
 }
 
 func New() (*Config, error) {
@@ -19,11 +35,87 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("startup: %w", err)
 	}
 
+	var pollInterval time.Duration
+	if raw := os.Getenv(startup.EnvConfigPollKey); raw != "" {
+		pollInterval, err = time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", startup.EnvConfigPollKey, err)
+		}
+	}
+
+	var batchWindow time.Duration
+	if raw := os.Getenv(startup.EnvConfigBatchKey); raw != "" {
+		batchWindow, err = time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", startup.EnvConfigBatchKey, err)
+		}
+	}
+
+	var sourceInterval time.Duration
+	if raw := os.Getenv(startup.EnvConfigSourceIntervalKey); raw != "" {
+		sourceInterval, err = time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", startup.EnvConfigSourceIntervalKey, err)
+		}
+	}
+
+	var writeBack bool
+	if raw := os.Getenv(startup.EnvConfigWriteBackKey); raw != "" {
+		writeBack, err = strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", startup.EnvConfigWriteBackKey, err)
+		}
+	}
+
+	var reflectionScan bool
+	if raw := os.Getenv(startup.EnvModuleReflectionScanKey); raw != "" {
+		reflectionScan, err = strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", startup.EnvModuleReflectionScanKey, err)
+		}
+	}
+
+	apiKeys, err := parseAPIKeys(os.Getenv(startup.EnvAPIKeysKey))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", startup.EnvAPIKeysKey, err)
+	}
+
 	return &Config{
 		BotToken: sConf.Token,
 		Prefix:   sConf.Prefix,
 		AppID:    sConf.AppID,
 		GuildID:  sConf.GuildID,
 		Port:     sConf.Port,
+
+		ConfigPollInterval: pollInterval,
+		ConfigWriteBack:    writeBack,
+		ConfigBatchWindow:  batchWindow,
+
+		ConfigSource:         os.Getenv(startup.EnvConfigSourceKey),
+		ConfigSourceInterval: sourceInterval,
+
+		APIKeys: apiKeys,
+
+		ModuleReflectionScan: reflectionScan,
 	}, nil
 }
+
+// parseAPIKeys reads "identity:key" pairs separated by commas.
+func parseAPIKeys(raw string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for i, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		identity, key, ok := strings.Cut(pair, ":")
+		if !ok || identity == "" || key == "" {
+			return nil, fmt.Errorf("entry %d must be identity:key", i+1)
+		}
+		if _, exists := keys[key]; exists {
+			return nil, fmt.Errorf("duplicate key for %s", identity)
+		}
+		keys[key] = identity
+	}
+	return keys, nil
+}
@@ -0,0 +1,301 @@
+package config_manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type AuditKind string
+
+const (
+	AuditUnusedFile   AuditKind = "unused_file"
+	AuditOrphanMerge  AuditKind = "orphan_merge"
+	AuditStaleKey     AuditKind = "stale_key"
+	AuditDefaultValue AuditKind = "default_value"
+	AuditPlaceholder  AuditKind = "placeholder"
+)
+
+type AuditFinding struct {
+	Kind    AuditKind `json:"kind"`
+	Config  string    `json:"config,omitempty"`
+	File    string    `json:"file,omitempty"`
+	Key     string    `json:"key,omitempty"`
+	Line    int       `json:"line,omitempty"`
+	Message string    `json:"message"`
+}
+
+type AuditReport struct {
+	Findings     []AuditFinding `json:"findings"`
+	EnvOverrides []EnvOverride  `json:"env_overrides,omitempty"`
+	Secrets      []SecretRef    `json:"secrets,omitempty"`
+}
+
+func (r AuditReport) Count(kind AuditKind) int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *Manager) Audit() AuditReport {
+	scan := m.ScanUsage()
+	report := AuditReport{
+		Findings:     []AuditFinding{},
+		EnvOverrides: scan.EnvOverrides,
+		Secrets:      scan.Secrets,
+	}
+
+	for _, f := range scan.Files {
+		report.Findings = append(report.Findings, m.auditFile(f)...)
+	}
+
+	m.mu.RLock()
+	metas := make([]*ConfigMeta, 0, len(m.registry))
+	for _, meta := range m.registry {
+		metas = append(metas, meta)
+	}
+	m.mu.RUnlock()
+
+	for _, meta := range metas {
+		report.Findings = append(report.Findings, m.auditConfig(meta)...)
+	}
+
+	sort.SliceStable(
+		report.Findings, func(i, j int) bool {
+			a, b := report.Findings[i], report.Findings[j]
+			if a.Config != b.Config {
+				return a.Config < b.Config
+			}
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		},
+	)
+	return report
+}
+
+func (m *Manager) auditFile(f FileStatus) []AuditFinding {
+	stem, _ := configStem(filepath.Base(f.Path))
+
+	if filepath.Dir(f.Path) == filepath.Clean(m.pathDefault) {
+		if f.IsUsed {
+			return nil
+		}
+		return []AuditFinding{
+			{
+				Kind:    AuditUnusedFile,
+				Config:  stem,
+				File:    f.Path,
+				Message: "base file is not used by any registered module",
+			},
+		}
+	}
+
+	name := strings.TrimPrefix(stem, PrefixMerge)
+	if _, found := m.locateFile(m.pathDefault, name); len(found) == 0 {
+		return []AuditFinding{
+			{
+				Kind:    AuditOrphanMerge,
+				Config:  name,
+				File:    f.Path,
+				Message: "MERGE file has no base file in " + m.pathDefault,
+			},
+		}
+	}
+	if !f.IsUsed {
+		return []AuditFinding{
+			{
+				Kind:    AuditUnusedFile,
+				Config:  name,
+				File:    f.Path,
+				Message: "MERGE file is not used by any registered module",
+			},
+		}
+	}
+	return nil
+}
+
+func (m *Manager) auditConfig(meta *ConfigMeta) []AuditFinding {
+	m.mu.RLock()
+	placeholder := meta.CurrentValue == nil
+	m.mu.RUnlock()
+
+	if placeholder {
+		return []AuditFinding{
+			{
+				Kind:    AuditPlaceholder,
+				Config:  meta.Name,
+				File:    m.basePath(meta.Name),
+				Message: "configuration is registered only as a placeholder",
+			},
+		}
+	}
+
+	var findings []AuditFinding
+
+	mergeFiles := []string{m.mergePath(meta.Name)}
+	if entries, err := os.ReadDir(m.guildsDir()); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				mergeFiles = append(mergeFiles, m.guildMergePath(meta.Name, e.Name()))
+			}
+		}
+	}
+
+	for _, path := range mergeFiles {
+		doc, data, ok := m.auditDoc(meta.Name, path)
+		if !ok {
+			continue
+		}
+		index := indexDocKeys(doc)
+		for _, key := range staleKeys(data, meta.StructType, "") {
+			findings = append(
+				findings, AuditFinding{
+					Kind:    AuditStaleKey,
+					Config:  meta.Name,
+					File:    path,
+					Key:     key,
+					Line:    keyLine(index, key),
+					Message: "key does not exist in the config struct",
+				},
+			)
+		}
+	}
+
+	defaults, err := toYamlMap(meta.template)
+	if err != nil || meta.template == nil {
+		return findings
+	}
+
+	basePath := m.basePath(meta.Name)
+	if doc, data, ok := m.auditDoc(meta.Name, basePath); ok {
+		index := indexDocKeys(doc)
+		for _, key := range defaultKeys(data, defaults, "") {
+			findings = append(
+				findings, AuditFinding{
+					Kind:    AuditDefaultValue,
+					Config:  meta.Name,
+					File:    basePath,
+					Key:     key,
+					Line:    keyLine(index, key),
+					Message: "value equals the module template default; template defaults are not applied by the loader, so removing the key leaves the zero value",
+				},
+			)
+		}
+	}
+
+	return findings
+}
+
+func (m *Manager) auditDoc(
+	name, path string,
+) (sourceDoc, map[string]any, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return sourceDoc{}, nil, false
+	}
+
+	data, err := codecFor(path).Decode(content)
+	if err != nil {
+		return sourceDoc{}, nil, false
+	}
+
+	data, _, err = m.migrate(name, path, data)
+	if err != nil {
+		return sourceDoc{}, nil, false
+	}
+	return sourceDoc{Path: path, Content: content}, data, true
+}
+
+func staleKeys(
+	value any,
+	t reflect.Type,
+	prefix string,
+) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var stale []string
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if strings.HasPrefix(k, DirectivePrefix) {
+				continue
+			}
+			path := joinYamlPath(prefix, k)
+
+			switch t.Kind() {
+			case reflect.Struct:
+				field, name, ok := findFieldByYamlKey(t, k)
+				if !ok || name != k {
+					stale = append(stale, path)
+					continue
+				}
+				stale = append(stale, staleKeys(child, field.Type, path)...)
+			case reflect.Map:
+				stale = append(stale, staleKeys(child, t.Elem(), path)...)
+			}
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range v {
+				stale = append(stale, staleKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i))...)
+			}
+		}
+	}
+
+	sort.Strings(stale)
+	return stale
+}
+
+func defaultKeys(
+	data, defaults map[string]any,
+	prefix string,
+) []string {
+	var keys []string
+	for k, v := range data {
+		if strings.HasPrefix(k, DirectivePrefix) {
+			continue
+		}
+		def, ok := defaults[k]
+		if !ok {
+			continue
+		}
+
+		path := joinYamlPath(prefix, k)
+		dataMap, isDataMap := v.(map[string]any)
+		defMap, isDefMap := def.(map[string]any)
+		switch {
+		case isDataMap && isDefMap:
+			keys = append(keys, defaultKeys(dataMap, defMap, path)...)
+		case isDataMap || containsDirectives(v):
+			// Objects without a default object and directive nodes are not comparable.
+		case valuesEqual(v, def):
+			keys = append(keys, path)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func keyLine(
+	index []keyLocation,
+	path string,
+) int {
+	for _, loc := range index {
+		if loc.Path == path {
+			return loc.Line
+		}
+	}
+	return 0
+}
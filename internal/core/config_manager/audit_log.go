@@ -0,0 +1,195 @@
+package config_manager
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"DiscordBotAgent/pkg/ctxtrace"
+
+	"go.uber.org/zap"
+)
+
+type AuditOutcome string
+
+const (
+	AuditApplied  AuditOutcome = "applied"
+	AuditRejected AuditOutcome = "rejected"
+	AuditHeld     AuditOutcome = "held"
+)
+
+// AuditEntry is one line of the append-only change log in config_mrg/.history/audit.jsonl.
+type AuditEntry struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Config    string       `json:"config"`
+	Source    string       `json:"source"`
+	Actor     string       `json:"actor,omitempty"`
+	TraceID   string       `json:"trace_id"`
+	Outcome   AuditOutcome `json:"outcome"`
+	Stage     string       `json:"stage,omitempty"`
+	Changes   []Change     `json:"changes,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+type AuditQuery struct {
+	Config  string
+	Actor   string
+	Outcome AuditOutcome
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+}
+
+func (q AuditQuery) matches(e AuditEntry) bool {
+	switch {
+	case q.Config != "" && e.Config != q.Config:
+		return false
+	case q.Actor != "" && e.Actor != q.Actor:
+		return false
+	case q.Outcome != "" && e.Outcome != q.Outcome:
+		return false
+	case !q.Since.IsZero() && e.Timestamp.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && e.Timestamp.After(q.Until):
+		return false
+	}
+	return true
+}
+
+func (m *Manager) auditLogPath() string {
+	return filepath.Join(m.pathMerge, DirHistory, FileAuditLog)
+}
+
+func (m *Manager) recordAudit(
+	ctx context.Context,
+	entry AuditEntry,
+) {
+	if m.readOnly {
+		return
+	}
+
+	entry.Timestamp = time.Now()
+	entry.TraceID = ctxtrace.Extract(ctx)
+	entry.Actor = ctxtrace.ExtractActor(ctx)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		m.log.WithCtx(ctx).Error("failed to marshal audit entry", zap.String("config", entry.Config), zap.Error(err))
+		return
+	}
+
+	m.auditMu.Lock()
+	defer m.auditMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(m.auditLogPath()), 0750); err != nil {
+		m.log.WithCtx(ctx).Error("failed to create audit log directory", zap.Error(err))
+		return
+	}
+
+	f, err := os.OpenFile(m.auditLogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		m.log.WithCtx(ctx).Error("failed to open audit log", zap.Error(err))
+		return
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		m.log.WithCtx(ctx).Error("failed to append audit entry", zap.String("config", entry.Config), zap.Error(err))
+	}
+}
+
+func (m *Manager) recordRejection(
+	ctx context.Context,
+	name, source, stage string,
+	err error,
+) {
+	m.recordAudit(
+		ctx, AuditEntry{
+			Config:  name,
+			Source:  source,
+			Outcome: AuditRejected,
+			Stage:   stage,
+			Error:   err.Error(),
+			Errors:  errorFields(err),
+		},
+	)
+}
+
+// AuditLog returns matching entries in chronological order, limited to the most recent q.Limit.
+func (m *Manager) AuditLog(q AuditQuery) ([]AuditEntry, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultAuditLimit
+	}
+
+	m.auditMu.Lock()
+	defer m.auditMu.Unlock()
+
+	f, err := os.Open(m.auditLogPath())
+	if os.IsNotExist(err) {
+		return []AuditEntry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	entries := make([]AuditEntry, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxAuditLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			m.log.Warn("skipping malformed audit log line", zap.Int("line", line), zap.Error(err))
+			continue
+		}
+		if !q.matches(e) {
+			continue
+		}
+		entries = append(entries, e)
+		if len(entries) > q.Limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// writeOrigin is the API request behind a pending merge file write.
+type writeOrigin struct {
+	actor   string
+	traceID string
+}
+
+func originOf(ctx context.Context) writeOrigin {
+	return writeOrigin{actor: ctxtrace.ExtractActor(ctx), traceID: ctxtrace.Extract(ctx)}
+}
+
+// takeOrigin attributes the next reload of name to a pending API write, if any, and carries
+// the request's correlation ID so the reload logs and audit entry match the API call.
+func (m *Manager) takeOrigin(
+	ctx context.Context,
+	name string,
+) (context.Context, string) {
+	m.mu.Lock()
+	origin, ok := m.origins[name]
+	delete(m.origins, name)
+	m.mu.Unlock()
+
+	if !ok {
+		return ctx, SourceReload
+	}
+	if origin.traceID != "" {
+		ctx = ctxtrace.WithCorrelationID(ctx, origin.traceID)
+	}
+	return ctxtrace.WithActor(ctx, origin.actor), SourceAPI
+}
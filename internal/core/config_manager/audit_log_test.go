@@ -0,0 +1,177 @@
+package config_manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"DiscordBotAgent/pkg/ctxtrace"
+)
+
+func TestAuditLog_RecordsReloadOutcomes(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "audited.yaml")
+	writeYamlFile(t, path, "name: base\ncount: 1\n")
+	if err := m.Register("audited", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	writeYamlFile(t, path, "name: base\ncount: 2\n")
+	m.reloadConfig(ctxtrace.WithCorrelationID(context.Background(), "trace-ok"), "audited")
+	writeYamlFile(t, path, "name: base\ncount: 500\n")
+	m.reloadConfig(ctxtrace.WithCorrelationID(context.Background(), "trace-bad"), "audited")
+
+	entries, err := m.AuditLog(AuditQuery{Config: "audited"})
+	if err != nil {
+		t.Fatalf("AuditLog() error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+
+	if entries[0].Source != SourceRegister || entries[0].Outcome != AuditApplied {
+		t.Errorf("unexpected register entry: %+v", entries[0])
+	}
+
+	applied := entries[1]
+	if applied.Source != SourceReload || applied.Outcome != AuditApplied || applied.TraceID != "trace-ok" {
+		t.Errorf("unexpected applied entry: %+v", applied)
+	}
+	if len(applied.Changes) != 1 || applied.Changes[0].Path != "count" {
+		t.Errorf("expected count change in applied entry, got %+v", applied.Changes)
+	}
+
+	rejected := entries[2]
+	if rejected.Outcome != AuditRejected || rejected.Stage != "validation" || rejected.TraceID != "trace-bad" {
+		t.Errorf("unexpected rejected entry: %+v", rejected)
+	}
+	if len(rejected.Errors) == 0 || rejected.Errors[0].Path != "count" {
+		t.Errorf("expected field errors in rejected entry, got %+v", rejected.Errors)
+	}
+}
+
+func TestAuditLog_AttributesAPIWritesToActor(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "attributed.yaml"), "name: base\ncount: 1\n")
+	if err := m.Register("attributed", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	ctx := ctxtrace.WithCorrelationID(ctxtrace.WithActor(context.Background(), "alice"), "api-request")
+	if _, err := m.PatchMerge(ctx, "attributed", map[string]any{"count": 500}); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if _, err := m.PatchMerge(ctx, "attributed", map[string]any{"count": 3}); err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+	m.reloadConfig(ctxtrace.WithCorrelationID(context.Background(), "watcher"), "attributed")
+
+	entries, err := m.AuditLog(AuditQuery{Config: "attributed", Actor: "alice"})
+	if err != nil {
+		t.Fatalf("AuditLog() error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries by alice, got %+v", entries)
+	}
+	if entries[0].Source != SourceAPI || entries[0].Outcome != AuditRejected {
+		t.Errorf("unexpected rejected API entry: %+v", entries[0])
+	}
+	if entries[1].Source != SourceAPI || entries[1].Outcome != AuditApplied || len(entries[1].Changes) != 1 {
+		t.Errorf("unexpected applied API entry: %+v", entries[1])
+	}
+	if entries[1].TraceID != "api-request" {
+		t.Errorf("expected the API request trace on the applied entry, got %q", entries[1].TraceID)
+	}
+}
+
+func TestAuditLog_UnchangedWriteDoesNotClaimNextReload(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "unclaimed.yaml")
+	writeYamlFile(t, path, "name: base\ncount: 1\n")
+	if err := m.Register("unclaimed", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	ctx := ctxtrace.WithActor(context.Background(), "alice")
+	if _, err := m.PatchMerge(ctx, "unclaimed", map[string]any{"count": 3}); err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+	m.reloadConfig(context.Background(), "unclaimed")
+	if _, err := m.PatchMerge(ctx, "unclaimed", map[string]any{"count": 3}); err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+
+	writeYamlFile(t, path, "name: edited\ncount: 1\n")
+	m.reloadConfig(context.Background(), "unclaimed")
+
+	entries, err := m.AuditLog(AuditQuery{Config: "unclaimed", Outcome: AuditApplied})
+	if err != nil {
+		t.Fatalf("AuditLog() error: %v", err)
+	}
+	last := entries[len(entries)-1]
+	if last.Source != SourceReload || last.Actor != "" {
+		t.Errorf("file edit must not be attributed to the API write, got %+v", last)
+	}
+}
+
+func TestAuditLog_SkipsMalformedLines(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "first.yaml"), "name: a\n")
+	_ = m.Register("first", TestConfig{}, nil)
+
+	f, err := os.OpenFile(m.auditLogPath(), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+	_, _ = f.WriteString("{not json\n")
+	_ = f.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "second.yaml"), "name: b\n")
+	_ = m.Register("second", TestConfig{}, nil)
+
+	entries, err := m.AuditLog(AuditQuery{})
+	if err != nil {
+		t.Fatalf("AuditLog() error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Config != "first" || entries[1].Config != "second" {
+		t.Errorf("expected the valid entries around the bad line, got %+v", entries)
+	}
+}
+
+func TestAuditLog_Filters(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "first.yaml"), "name: a\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "second.yaml"), "count: 1\n")
+	_ = m.Register("first", TestConfig{}, nil)
+	_ = m.Register("second", TestConfig{}, nil)
+
+	rejected, err := m.AuditLog(AuditQuery{Outcome: AuditRejected})
+	if err != nil {
+		t.Fatalf("AuditLog() error: %v", err)
+	}
+	if len(rejected) != 1 || rejected[0].Config != "second" {
+		t.Errorf("expected only the rejected registration, got %+v", rejected)
+	}
+
+	future, _ := m.AuditLog(AuditQuery{Since: time.Now().Add(time.Hour)})
+	if len(future) != 0 {
+		t.Errorf("expected no entries after the since filter, got %+v", future)
+	}
+
+	limited, _ := m.AuditLog(AuditQuery{Limit: 1})
+	if len(limited) != 1 || limited[0].Config != "second" {
+		t.Errorf("expected the most recent entry, got %+v", limited)
+	}
+}
@@ -0,0 +1,60 @@
+package config_manager
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func findingsOf(
+	report AuditReport,
+	kind AuditKind,
+) []AuditFinding {
+	var result []AuditFinding
+	for _, f := range report.Findings {
+		if f.Kind == kind {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func TestAudit_ReportsOrphanAndStaleMergeFiles(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "audited.yaml"), "name: base\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.gone.yaml"), "count: 2\n")
+	writeYamlFile(t, filepath.Join(mergePath, DirGuilds, "123456789012345678", "MERGE.audited.yaml"), "count: 2\nlegacy: true\n")
+	if err := m.Register("audited", TestConfig{Count: 1}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	report := m.Audit()
+
+	orphans := findingsOf(report, AuditOrphanMerge)
+	if len(orphans) != 1 || orphans[0].Config != "gone" {
+		t.Errorf("unexpected orphan findings: %+v", orphans)
+	}
+
+	stale := findingsOf(report, AuditStaleKey)
+	if len(stale) != 1 || stale[0].Key != "legacy" || stale[0].Line != 2 {
+		t.Errorf("unexpected stale findings: %+v", stale)
+	}
+
+	defaults := findingsOf(report, AuditDefaultValue)
+	if len(defaults) != 1 || defaults[0].Key != "count" {
+		t.Errorf("unexpected default findings: %+v", defaults)
+	}
+}
+
+func TestAudit_ReportsPlaceholders(t *testing.T) {
+	m, _, _ := setupTestManager(t)
+	defer m.Close()
+
+	_ = m.Register("pending", TestConfig{}, nil)
+
+	placeholders := findingsOf(m.Audit(), AuditPlaceholder)
+	if len(placeholders) != 1 || placeholders[0].Config != "pending" {
+		t.Errorf("unexpected placeholder findings: %+v", placeholders)
+	}
+}
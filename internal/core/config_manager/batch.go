@@ -0,0 +1,193 @@
+package config_manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"DiscordBotAgent/pkg/ctxtrace"
+
+	"go.uber.org/zap"
+)
+
+type appliedState struct {
+	value   any
+	secrets *secretSet
+}
+
+type stagedReload struct {
+	ctx    context.Context
+	source string
+	meta   *ConfigMeta
+	res    *loadResult
+}
+
+func (m *Manager) scheduleBatch(
+	ctx context.Context,
+	configName string,
+) {
+	m.mu.Lock()
+	m.batchPending[configName] = true
+	if m.batchTimer != nil {
+		m.batchTimer.Stop()
+	}
+	m.batchTimer = m.afterFunc(m.batchWindow, m.reloadBatch)
+	m.mu.Unlock()
+
+	m.log.WithCtx(ctx).Debug(
+		"reload added to batch",
+		zap.String("config", configName),
+		zap.Duration("window", m.batchWindow),
+	)
+}
+
+func (m *Manager) reloadBatch() {
+	m.mu.Lock()
+	members := make(map[string]bool, len(m.batchPending)+len(m.batchHeld))
+	for name := range m.batchPending {
+		members[name] = true
+	}
+	for name := range m.batchHeld {
+		members[name] = true
+	}
+	m.batchPending = make(map[string]bool)
+	m.batchHeld = make(map[string]bool)
+	m.batchTimer = nil
+	m.mu.Unlock()
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx := ctxtrace.WithCorrelationID(context.Background(), m.generateTraceID())
+	m.log.WithCtx(ctx).Info("batch reload started", zap.Strings("configs", names))
+
+	var staged []stagedReload
+	var failed []string
+	for _, name := range names {
+		memberCtx, source := m.takeOrigin(ctx, name)
+		meta, res, stage, err := m.stageReload(memberCtx, name)
+		if err == nil && res != nil {
+			if err = m.validate(name, res); err != nil {
+				stage = "validation"
+			}
+		}
+		if err != nil {
+			failed = append(failed, name)
+			m.rejectReload(memberCtx, meta, source, stage, err)
+			continue
+		}
+		if res != nil {
+			staged = append(staged, stagedReload{ctx: memberCtx, source: source, meta: meta, res: res})
+		}
+	}
+
+	if len(failed) > 0 {
+		for _, s := range staged {
+			m.holdReload(s.ctx, s.meta, s.source, failed)
+		}
+		m.log.WithCtx(ctx).Error(
+			"batch reload rejected: no configuration applied",
+			zap.Strings("failed", failed),
+			zap.Int("held", len(staged)),
+		)
+		return
+	}
+	if len(staged) == 0 {
+		return
+	}
+
+	staged = dependencyOrder(staged)
+
+	m.mu.Lock()
+	previous := make([]appliedState, len(staged))
+	for i, s := range staged {
+		previous[i] = m.swapConfig(s.meta, s.res)
+	}
+	m.mu.Unlock()
+
+	events := make([]UpdateEvent, len(staged))
+	order := make([]string, len(staged))
+	for i, s := range staged {
+		events[i] = m.finishApply(s.ctx, s.meta, s.res, previous[i], s.source)
+		order[i] = s.meta.Name
+	}
+
+	m.log.WithCtx(ctx).Info("batch reload applied", zap.Strings("order", order))
+
+	go func() {
+		for i, s := range staged {
+			s.meta.notify(ctx, events[i])
+		}
+	}()
+}
+
+func (m *Manager) holdReload(
+	ctx context.Context,
+	meta *ConfigMeta,
+	source string,
+	failed []string,
+) {
+	err := fmt.Errorf("%w: %s failed", ErrBatchRejected, strings.Join(failed, ", "))
+
+	m.recordAudit(
+		ctx, AuditEntry{
+			Config:  meta.Name,
+			Source:  source,
+			Outcome: AuditHeld,
+			Error:   err.Error(),
+		},
+	)
+
+	m.mu.Lock()
+	if source == SourceAPI {
+		m.origins[meta.Name] = originOf(ctx)
+	}
+	// Forget the hash so the held change is loaded again with the next batch.
+	meta.sourceHash = ""
+	meta.Rejected = &RejectedRevision{
+		TraceID:    ctxtrace.Extract(ctx),
+		Error:      err.Error(),
+		RejectedAt: time.Now(),
+	}
+	m.batchHeld[meta.Name] = true
+	m.mu.Unlock()
+
+	m.log.WithCtx(ctx).Warn(
+		"configuration change held back by failed batch",
+		zap.String("config", meta.Name),
+		zap.Error(err),
+	)
+}
+
+func dependencyOrder(staged []stagedReload) []stagedReload {
+	byName := make(map[string]stagedReload, len(staged))
+	for _, s := range staged {
+		byName[s.meta.Name] = s
+	}
+
+	ordered := make([]stagedReload, 0, len(staged))
+	visited := make(map[string]bool, len(staged))
+
+	var visit func(name string)
+	visit = func(name string) {
+		s, ok := byName[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dep := range s.meta.dependsOn {
+			visit(dep)
+		}
+		ordered = append(ordered, s)
+	}
+
+	for _, s := range staged {
+		visit(s.meta.Name)
+	}
+	return ordered
+}
@@ -0,0 +1,118 @@
+package config_manager
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func registerBatchMember(
+	t *testing.T,
+	m *Manager,
+	name string,
+	applied chan string,
+	opts ...Option,
+) {
+	t.Helper()
+
+	opts = append(
+		opts, WithChangeCallback(
+			func(
+				ctx context.Context,
+				event UpdateEvent,
+			) {
+				if event.Valid && event.Changes != nil {
+					applied <- name
+				}
+			},
+		),
+	)
+	if err := m.Register(name, TestConfig{}, nil, opts...); err != nil {
+		t.Fatalf("Register(%s) error: %v", name, err)
+	}
+}
+
+func enableManualBatch(m *Manager) {
+	m.batchWindow = time.Minute
+	m.afterFunc = func(
+		time.Duration,
+		func(),
+	) *time.Timer {
+		return time.NewTimer(time.Hour)
+	}
+}
+
+func receiveApplied(
+	t *testing.T,
+	applied chan string,
+) string {
+	t.Helper()
+	select {
+	case name := <-applied:
+		return name
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for applied config")
+		return ""
+	}
+}
+
+func TestReloadBatch_AppliesInDependencyOrder(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	enableManualBatch(m)
+	applied := make(chan string, 4)
+	writeYamlFile(t, filepath.Join(defaultPath, "beta.yaml"), "name: beta\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "alpha.yaml"), "name: alpha\ncount: 1\n")
+	registerBatchMember(t, m, "beta", applied, WithDependsOn("alpha"))
+	registerBatchMember(t, m, "alpha", applied)
+
+	writeYamlFile(t, filepath.Join(defaultPath, "beta.yaml"), "name: beta\ncount: 2\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "alpha.yaml"), "name: alpha\ncount: 2\n")
+	m.scheduleReload("beta", "Write", "")
+	m.scheduleReload("alpha", "Write", "")
+	m.reloadBatch()
+
+	if first, second := receiveApplied(t, applied), receiveApplied(t, applied); first != "alpha" || second != "beta" {
+		t.Errorf("expected alpha before beta, got %s, %s", first, second)
+	}
+}
+
+func TestReloadBatch_FailedMemberHoldsBackOthers(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	enableManualBatch(m)
+	applied := make(chan string, 4)
+	writeYamlFile(t, filepath.Join(defaultPath, "alpha.yaml"), "name: alpha\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "beta.yaml"), "name: beta\ncount: 1\n")
+	registerBatchMember(t, m, "alpha", applied)
+	registerBatchMember(t, m, "beta", applied, WithDependsOn("alpha"))
+
+	writeYamlFile(t, filepath.Join(defaultPath, "alpha.yaml"), "name: alpha\ncount: 2\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "beta.yaml"), "name: beta\ncount: 500\n")
+	m.scheduleReload("alpha", "Write", "")
+	m.scheduleReload("beta", "Write", "")
+	m.reloadBatch()
+
+	if cfg := m.Get("alpha").(TestConfig); cfg.Count != 1 {
+		t.Errorf("alpha must not be applied while beta fails, got %+v", cfg)
+	}
+	info, _ := m.Info("alpha")
+	if info.Rejected == nil || !strings.Contains(info.Rejected.Error, ErrBatchRejected.Error()) {
+		t.Errorf("alpha must report the held batch, got %+v", info.Rejected)
+	}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "beta.yaml"), "name: beta\ncount: 3\n")
+	m.scheduleReload("beta", "Write", "")
+	m.reloadBatch()
+
+	if first, second := receiveApplied(t, applied), receiveApplied(t, applied); first != "alpha" || second != "beta" {
+		t.Errorf("expected alpha before beta, got %s, %s", first, second)
+	}
+	if cfg := m.Get("alpha").(TestConfig); cfg.Count != 2 {
+		t.Errorf("held alpha change must be applied with the next batch, got %+v", cfg)
+	}
+}
@@ -0,0 +1,199 @@
+package config_manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+type Codec interface {
+	Extensions() []string
+	Decode(content []byte) (map[string]any, error)
+	Encode(data map[string]any) ([]byte, error)
+}
+
+var codecs = []Codec{
+	yamlCodec{},
+	jsonCodec{},
+	tomlCodec{},
+}
+
+var configExtensions = func() []string {
+	var exts []string
+	for _, c := range codecs {
+		exts = append(exts, c.Extensions()...)
+	}
+	return exts
+}()
+
+func codecFor(path string) Codec {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, c := range codecs {
+		for _, e := range c.Extensions() {
+			if e == ext {
+				return c
+			}
+		}
+	}
+	return yamlCodec{}
+}
+
+func configStem(fileName string) (string, bool) {
+	for _, ext := range configExtensions {
+		if strings.HasSuffix(fileName, ext) {
+			return strings.TrimSuffix(fileName, ext), true
+		}
+	}
+	return "", false
+}
+
+func isYamlReadable(path string) bool {
+	switch codecFor(path).(type) {
+	case yamlCodec, jsonCodec:
+		return true
+	}
+	return false
+}
+
+type decodeError struct {
+	Line    int
+	Column  int
+	Message string
+	err     error
+}
+
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Extensions() []string {
+	return []string{ExtensionYaml, ExtensionYml}
+}
+
+func (yamlCodec) Decode(content []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		dErr := &decodeError{Message: err.Error(), err: err}
+		if match := yamlSyntaxErrRe.FindStringSubmatch(err.Error()); match != nil {
+			dErr.Line, _ = strconv.Atoi(match[1])
+			dErr.Message = match[2]
+		}
+		return nil, dErr
+	}
+	return data, nil
+}
+
+func (yamlCodec) Encode(data map[string]any) ([]byte, error) {
+	return yaml.Marshal(data)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Extensions() []string {
+	return []string{ExtensionJson}
+}
+
+func (jsonCodec) Decode(content []byte) (map[string]any, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return make(map[string]any), nil
+	}
+
+	var probe map[string]any
+	if err := json.Unmarshal(content, &probe); err != nil {
+		dErr := &decodeError{Message: err.Error(), err: err}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			dErr.Line, dErr.Column = offsetPosition(content, syntaxErr.Offset)
+		}
+		return nil, dErr
+	}
+
+	// JSON is re-read through YAML so integers keep their type instead of becoming float64.
+	data := make(map[string]any)
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, &decodeError{Message: err.Error(), err: err}
+	}
+	return data, nil
+}
+
+func (jsonCodec) Encode(data map[string]any) ([]byte, error) {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+type tomlCodec struct{}
+
+func (tomlCodec) Extensions() []string {
+	return []string{ExtensionToml}
+}
+
+func (tomlCodec) Decode(content []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	if err := toml.Unmarshal(content, &data); err != nil {
+		dErr := &decodeError{Message: err.Error(), err: err}
+		var tomlErr *toml.DecodeError
+		if errors.As(err, &tomlErr) {
+			dErr.Line, dErr.Column = tomlErr.Position()
+			dErr.Message = strings.TrimPrefix(err.Error(), "toml: ")
+		}
+		return nil, dErr
+	}
+	return data, nil
+}
+
+func (tomlCodec) Encode(data map[string]any) ([]byte, error) {
+	return toml.Marshal(data)
+}
+
+func offsetPosition(
+	content []byte,
+	offset int64,
+) (int, int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	line, column := 1, 1
+	for _, b := range content[:offset] {
+		if b == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return line, column
+}
+
+func formatConflictError(
+	name string,
+	paths []string,
+) *ValidationError {
+	message := fmt.Sprintf("multiple formats for the same configuration: %s", strings.Join(paths, ", "))
+	return &ValidationError{
+		Config: name,
+		Fields: []FieldError{
+			{
+				File:    paths[0],
+				Rule:    "format_conflict",
+				Message: message,
+			},
+		},
+		err: fmt.Errorf("%w: %s", ErrFormatConflict, message),
+	}
+}
@@ -0,0 +1,144 @@
+package config_manager
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad_JsonBaseWithYamlMerge(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "jsonbase.json"), `{"name": "json", "count": 5}`)
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.jsonbase.yml"), "enabled: true")
+
+	result, err := m.loadAndMerge("jsonbase", reflect.TypeOf(TestConfig{}))
+	if err != nil {
+		t.Fatalf("loadAndMerge() error: %v", err)
+	}
+
+	cfg := result.(TestConfig)
+	if cfg.Name != "json" || cfg.Count != 5 || !cfg.Enabled {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoad_TomlBaseWithNestedValues(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	toml := `
+name = "toml"
+max_logs = 20
+
+[logDetails]
+guild = true
+`
+	writeYamlFile(t, filepath.Join(defaultPath, "tomlbase.toml"), toml)
+
+	result, err := m.loadAndMerge("tomlbase", reflect.TypeOf(NestedTestConfig{}))
+	if err != nil {
+		t.Fatalf("loadAndMerge() error: %v", err)
+	}
+
+	cfg := result.(NestedTestConfig)
+	if cfg.Name != "toml" || cfg.MaxLogs != 20 || !cfg.Details.Guild {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoad_TomlSchemaVersion(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "tomlversion.toml"), "schema_version = 1\nname = \"toml\"\namount = 4\n")
+
+	err := m.Register("tomlversion", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if cfg := m.Get("tomlversion").(TestConfig); cfg.Count != 4 {
+		t.Errorf("expected migrated count 4, got %+v", cfg)
+	}
+}
+
+func TestLoad_FormatConflict(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "conflict.yaml"), "name: yaml")
+	writeYamlFile(t, filepath.Join(defaultPath, "conflict.json"), `{"name": "json"}`)
+
+	_, err := m.loadAndMerge("conflict", reflect.TypeOf(TestConfig{}))
+	if !errors.Is(err, ErrFormatConflict) {
+		t.Fatalf("expected ErrFormatConflict, got %v", err)
+	}
+
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "format_conflict" {
+		t.Errorf("unexpected field errors: %+v", fields)
+	}
+}
+
+func TestLoad_TomlUnknownFieldIsReported(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "tomlstrict.yaml"), "name: base")
+	mergeFile := filepath.Join(mergePath, "MERGE.tomlstrict.toml")
+	writeYamlFile(t, mergeFile, "cuont = 3\n")
+
+	_, err := m.load("tomlstrict", reflect.TypeOf(TestConfig{}))
+
+	fields := errorFields(err)
+	if len(fields) != 1 {
+		t.Fatalf("expected one field error, got %+v (%v)", fields, err)
+	}
+	if fields[0].Rule != "unknown_field" || fields[0].File != mergeFile || fields[0].Path != "cuont" {
+		t.Errorf("unexpected field error: %+v", fields[0])
+	}
+}
+
+func TestLoad_JsonSyntaxErrorHasLine(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "jsonsyntax.json"), "{\n  \"name\": \"x\",\n  \"count\": ,\n}")
+
+	_, err := m.load("jsonsyntax", reflect.TypeOf(TestConfig{}))
+
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "syntax" || fields[0].Line != 3 {
+		t.Errorf("expected syntax error on line 3, got %+v", fields)
+	}
+}
+
+func TestPatchMerge_KeepsMergeFileFormat(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "jsonmerge.yaml"), "name: base\ncount: 1")
+	mergeFile := filepath.Join(mergePath, "MERGE.jsonmerge.json")
+	writeYamlFile(t, mergeFile, `{"count": 2}`)
+
+	if err := m.Register("jsonmerge", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	if _, err := m.PatchMerge(t.Context(), "jsonmerge", map[string]any{"enabled": true}); err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+
+	content, _ := os.ReadFile(mergeFile)
+	var written map[string]any
+	if err := json.Unmarshal(content, &written); err != nil {
+		t.Fatalf("merge file is no longer JSON: %v\n%s", err, content)
+	}
+	if written["enabled"] != true || written["count"] != float64(2) {
+		t.Errorf("unexpected merge file content: %v", written)
+	}
+}
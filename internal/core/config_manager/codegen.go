@@ -0,0 +1,229 @@
+package config_manager
+
+//go:generate go run ./contractgen -dir ../../../config_df -out contract.go
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ContractNames lists the configuration names of the base files in dir.
+func ContractNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		stem, ok := configStem(e.Name())
+		if !ok || strings.HasPrefix(stem, PrefixMerge) {
+			continue
+		}
+		names = append(names, stem)
+	}
+	return names, nil
+}
+
+type contractNode struct {
+	field    string
+	path     []string
+	name     string
+	children []*contractNode
+}
+
+func (n *contractNode) child(field string) *contractNode {
+	for _, c := range n.children {
+		if c.field == field {
+			return c
+		}
+	}
+	c := &contractNode{field: field, path: append(append([]string{}, n.path...), field)}
+	n.children = append(n.children, c)
+	return c
+}
+
+func (n *contractNode) typeName() string {
+	return "contract" + strings.Join(n.path, "")
+}
+
+// GenerateContract renders the Go source of the typed contract for the given configuration names.
+func GenerateContract(
+	pkg string,
+	names []string,
+) ([]byte, error) {
+	names = uniqueSorted(names)
+	root := &contractNode{}
+
+	for _, name := range names {
+		node := root
+		for _, segment := range strings.Split(name, ".") {
+			field, err := contractIdent(segment)
+			if err != nil {
+				return nil, fmt.Errorf("config %q: %w", name, err)
+			}
+			if node.name != "" {
+				return nil, fmt.Errorf("config %q: %q is both a config and a group", name, node.name)
+			}
+			node = node.child(field)
+		}
+		if len(node.children) > 0 || node.name != "" {
+			return nil, fmt.Errorf("config %q: name is both a config and a group", name)
+		}
+		node.name = name
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by contractgen; DO NOT EDIT.\n\npackage %s\n\n", pkg)
+
+	b.WriteString("const (\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%s = %q\n", contractConst(name), name)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// Contract gives typed access to configuration names, e.g. Contract.System.Discord.Template.\n")
+	b.WriteString("var Contract = ")
+	writeContractValue(&b, root)
+	b.WriteString("\n\n")
+
+	b.WriteString("var contractNames = map[string]bool{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%s: true,\n", contractConst(name))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("type contract struct {\n")
+	writeContractFields(&b, root)
+	b.WriteString("}\n")
+	writeContractTypes(&b, root)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format contract: %w", err)
+	}
+	return src, nil
+}
+
+// InContract reports whether name is listed in the generated contract.
+func InContract(name string) bool {
+	return contractNames[name]
+}
+
+// ContractKeys returns the contract names in sorted order.
+func ContractKeys() []string {
+	keys := make([]string, 0, len(contractNames))
+	for name := range contractNames {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeContractValue(
+	b *bytes.Buffer,
+	node *contractNode,
+) {
+	if node.name != "" {
+		b.WriteString(contractConst(node.name))
+		return
+	}
+	if len(node.path) == 0 {
+		b.WriteString("contract{\n")
+	} else {
+		b.WriteString(node.typeName() + "{\n")
+	}
+	for _, c := range node.children {
+		b.WriteString(c.field + ": ")
+		writeContractValue(b, c)
+		b.WriteString(",\n")
+	}
+	b.WriteString("}")
+}
+
+func writeContractFields(
+	b *bytes.Buffer,
+	node *contractNode,
+) {
+	for _, c := range node.children {
+		if c.name != "" {
+			fmt.Fprintf(b, "\t%s string\n", c.field)
+			continue
+		}
+		fmt.Fprintf(b, "\t%s %s\n", c.field, c.typeName())
+	}
+}
+
+func writeContractTypes(
+	b *bytes.Buffer,
+	node *contractNode,
+) {
+	for _, c := range node.children {
+		if c.name != "" {
+			continue
+		}
+		fmt.Fprintf(b, "\ntype %s struct {\n", c.typeName())
+		writeContractFields(b, c)
+		b.WriteString("}\n")
+		writeContractTypes(b, c)
+	}
+}
+
+func contractConst(name string) string {
+	var b strings.Builder
+	b.WriteString("Config")
+	for _, segment := range strings.Split(name, ".") {
+		field, _ := contractIdent(segment)
+		b.WriteString(field)
+	}
+	return b.String()
+}
+
+// contractIdent turns a name segment such as "auto_mod" into an exported identifier ("AutoMod").
+func contractIdent(segment string) (string, error) {
+	var b strings.Builder
+	upper := true
+	for _, r := range segment {
+		switch {
+		case r == '_' || r == '-':
+			upper = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			b.WriteRune(r)
+		default:
+			return "", fmt.Errorf("segment %q contains %q", segment, r)
+		}
+	}
+
+	ident := b.String()
+	if !token.IsIdentifier(ident) || !token.IsExported(ident) {
+		return "", fmt.Errorf("segment %q does not form an exported identifier", segment)
+	}
+	return ident, nil
+}
+
+func uniqueSorted(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,82 @@
+package config_manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateContract_CheckedInContractIsCurrent(t *testing.T) {
+	names, err := ContractNames(filepath.Join("..", "..", "..", "config_df"))
+	if err != nil {
+		t.Fatalf("ContractNames() error: %v", err)
+	}
+
+	want, err := GenerateContract("config_manager", names)
+	if err != nil {
+		t.Fatalf("GenerateContract() error: %v", err)
+	}
+	got, err := os.ReadFile("contract.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Error("contract.go is stale, run go generate ./internal/core/config_manager")
+	}
+}
+
+func TestGenerateContract_BuildsConstantsAndAccessors(t *testing.T) {
+	src, err := GenerateContract("sample", []string{"system.auto_mod", "system.discord.welcome", "system.auto_mod"})
+	if err != nil {
+		t.Fatalf("GenerateContract() error: %v", err)
+	}
+
+	for _, want := range []string{
+		`ConfigSystemAutoMod        = "system.auto_mod"`,
+		`ConfigSystemDiscordWelcome = "system.discord.welcome"`,
+		"AutoMod: ConfigSystemAutoMod,",
+		"Welcome: ConfigSystemDiscordWelcome,",
+		"type contractSystemDiscord struct",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated contract missing %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerateContract_RejectsConflicts(t *testing.T) {
+	if _, err := GenerateContract("sample", []string{"system.discord", "system.discord.welcome"}); err == nil {
+		t.Error("expected error for a name that is both a config and a group")
+	}
+	if _, err := GenerateContract("sample", []string{"system.1st"}); err == nil {
+		t.Error("expected error for a segment that is not an identifier")
+	}
+}
+
+func TestContractNames_SkipsMergeAndHiddenFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeYamlFile(t, filepath.Join(dir, "system.a.yaml"), "")
+	writeYamlFile(t, filepath.Join(dir, "system.b.toml"), "")
+	writeYamlFile(t, filepath.Join(dir, "MERGE.system.a.yaml"), "")
+	writeYamlFile(t, filepath.Join(dir, "README.md"), "")
+	writeYamlFile(t, filepath.Join(dir, DirSchemas, "system.a.schema.json"), "")
+
+	names, err := ContractNames(dir)
+	if err != nil {
+		t.Fatalf("ContractNames() error: %v", err)
+	}
+	if strings.Join(names, ",") != "system.a,system.b" {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
+
+func TestInContract(t *testing.T) {
+	if !InContract(Contract.System.Discord.Template) {
+		t.Error("expected template config to be in the contract")
+	}
+	if InContract("system.discord.tickets") {
+		t.Error("configs without base files must not be in the contract")
+	}
+}
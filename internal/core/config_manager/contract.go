@@ -1,35 +1,40 @@
+// Code generated by contractgen; DO NOT EDIT.
+
 package config_manager
 
-// Contract предоставляет типизированный доступ к именам конфигурационных файлов.
-// Использование: config_manager.Contract.System.Discord.Template
-var Contract = struct {
-	System struct {
-		Discord struct {
-			Template  string
-			Template2 string
-			Tickets   string
-			Moderator string
-		}
-	}
-}{
-	System: struct {
-		Discord struct {
-			Template  string
-			Template2 string
-			Tickets   string
-			Moderator string
-		}
-	}{
-		Discord: struct {
-			Template  string
-			Template2 string
-			Tickets   string
-			Moderator string
-		}{
-			Template:  "system.discord.template",
-			Template2: "system.discord.template2",
-			Tickets:   "system.discord.tickets",
-			Moderator: "system.discord.moderator",
+const (
+	ConfigSystemDiscordTemplate  = "system.discord.template"
+	ConfigSystemDiscordTemplate2 = "system.discord.template2"
+	ConfigSystemFeatureFlags     = "system.feature_flags"
+)
+
+// Contract gives typed access to configuration names, e.g. Contract.System.Discord.Template.
+var Contract = contract{
+	System: contractSystem{
+		Discord: contractSystemDiscord{
+			Template:  ConfigSystemDiscordTemplate,
+			Template2: ConfigSystemDiscordTemplate2,
 		},
+		FeatureFlags: ConfigSystemFeatureFlags,
 	},
 }
+
+var contractNames = map[string]bool{
+	ConfigSystemDiscordTemplate:  true,
+	ConfigSystemDiscordTemplate2: true,
+	ConfigSystemFeatureFlags:     true,
+}
+
+type contract struct {
+	System contractSystem
+}
+
+type contractSystem struct {
+	Discord      contractSystemDiscord
+	FeatureFlags string
+}
+
+type contractSystemDiscord struct {
+	Template  string
+	Template2 string
+}
@@ -0,0 +1,52 @@
+// Command contractgen writes the typed configuration contract from the config_df tree and explicit names.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"DiscordBotAgent/internal/core/config_manager"
+)
+
+type nameList []string
+
+func (n *nameList) String() string {
+	return strings.Join(*n, ",")
+}
+
+func (n *nameList) Set(value string) error {
+	*n = append(*n, value)
+	return nil
+}
+
+func main() {
+	var names nameList
+	dir := flag.String("dir", "", "directory with base configuration files")
+	out := flag.String("out", "contract.go", "output file")
+	pkg := flag.String("pkg", "config_manager", "package name of the generated file")
+	flag.Var(&names, "name", "configuration name to include (repeatable)")
+	flag.Parse()
+
+	if *dir != "" {
+		found, err := config_manager.ContractNames(*dir)
+		if err != nil {
+			log.Fatalf("contractgen: %v", err)
+		}
+		names = append(names, found...)
+	}
+	if len(names) == 0 {
+		log.Fatal("contractgen: no configuration names, pass -dir or -name")
+	}
+
+	src, err := config_manager.GenerateContract(*pkg, names)
+	if err != nil {
+		log.Fatalf("contractgen: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatalf("contractgen: %v", err)
+	}
+	fmt.Println(*out)
+}
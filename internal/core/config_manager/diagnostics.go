@@ -0,0 +1,334 @@
+package config_manager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlLineErrRe      = regexp.MustCompile(`^line (\d+): (.*)$`)
+	yamlUnknownKeyRe   = regexp.MustCompile(`^field (\S+) not found in type`)
+	yamlTypeMismatchRe = regexp.MustCompile(`^cannot unmarshal (\S+) .* into (\S+)$`)
+	yamlSyntaxErrRe    = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+)
+
+type sourceDoc struct {
+	Path    string
+	Content []byte
+}
+
+type keyLocation struct {
+	Path   string
+	Line   int
+	Column int
+}
+
+func readSourceDocs(
+	paths []string,
+	overlay []sourceDoc,
+) []sourceDoc {
+	docs := make([]sourceDoc, 0, len(paths))
+	for _, p := range paths {
+		if doc, ok := findSourceDoc(overlay, p); ok {
+			docs = append(docs, doc)
+			continue
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		docs = append(docs, sourceDoc{Path: p, Content: content})
+	}
+	return docs
+}
+
+func findSourceDoc(
+	docs []sourceDoc,
+	path string,
+) (sourceDoc, bool) {
+	for _, doc := range docs {
+		if doc.Path == path {
+			return doc, true
+		}
+	}
+	return sourceDoc{}, false
+}
+
+func (m *Manager) validate(
+	name string,
+	res *loadResult,
+) error {
+	err := m.validator.Validate(name, res.Value)
+
+	var vErr *ValidationError
+	if errors.As(err, &vErr) {
+		m.locateFieldErrors(vErr, readSourceDocs(res.Sources, res.overlay), res.EnvOverrides)
+	}
+	return err
+}
+
+func indexYamlKeys(content []byte) []keyLocation {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil
+	}
+
+	var locations []keyLocation
+	var walk func(node *yaml.Node, prefix string)
+	walk = func(node *yaml.Node, prefix string) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, prefix)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				path := key.Value
+				if prefix != "" {
+					path = prefix + "." + key.Value
+				}
+				locations = append(locations, keyLocation{Path: path, Line: key.Line, Column: key.Column})
+				walk(value, path)
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				path := fmt.Sprintf("%s[%d]", prefix, i)
+				locations = append(locations, keyLocation{Path: path, Line: item.Line, Column: item.Column})
+				walk(item, path)
+			}
+		}
+	}
+	walk(&root, "")
+
+	return locations
+}
+
+func indexDocKeys(doc sourceDoc) []keyLocation {
+	if isYamlReadable(doc.Path) {
+		return indexYamlKeys(doc.Content)
+	}
+
+	data, err := codecFor(doc.Path).Decode(doc.Content)
+	if err != nil {
+		return nil
+	}
+
+	var locations []keyLocation
+	var walk func(value any, prefix string)
+	walk = func(value any, prefix string) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, child := range v {
+				path := key
+				if prefix != "" {
+					path = prefix + "." + key
+				}
+				locations = append(locations, keyLocation{Path: path})
+				walk(child, path)
+			}
+		case []any:
+			for i, item := range v {
+				path := fmt.Sprintf("%s[%d]", prefix, i)
+				locations = append(locations, keyLocation{Path: path})
+				walk(item, path)
+			}
+		}
+	}
+	walk(data, "")
+
+	return locations
+}
+
+func (m *Manager) locateFieldErrors(
+	vErr *ValidationError,
+	docs []sourceDoc,
+	overrides []EnvOverride,
+) {
+	indexes := make([][]keyLocation, len(docs))
+	for i, doc := range docs {
+		indexes[i] = indexDocKeys(doc)
+	}
+
+	for i := range vErr.Fields {
+		fe := &vErr.Fields[i]
+		if fe.Path == "" || fe.File != "" {
+			continue
+		}
+
+		if o, ok := findEnvOverride(overrides, fe.Path); ok {
+			fe.File = "env:" + o.Variable
+			continue
+		}
+
+		located := false
+		for d := len(docs) - 1; d >= 0 && !located; d-- {
+			for _, loc := range indexes[d] {
+				if loc.Path == fe.Path {
+					fe.File = docs[d].Path
+					fe.Line = loc.Line
+					fe.Column = loc.Column
+					located = true
+					break
+				}
+			}
+		}
+
+		if !located && len(docs) > 0 {
+			fe.File = docs[0].Path
+		}
+	}
+}
+
+func findEnvOverride(
+	overrides []EnvOverride,
+	path string,
+) (EnvOverride, bool) {
+	for _, o := range overrides {
+		if o.Key == path || strings.HasPrefix(path, o.Key+".") || strings.HasPrefix(path, o.Key+"[") {
+			return o, true
+		}
+	}
+	return EnvOverride{}, false
+}
+
+func diagnoseDecode(
+	name string,
+	t reflect.Type,
+	docs []sourceDoc,
+	cause error,
+) *ValidationError {
+	result := &ValidationError{Config: name, err: cause}
+
+	for _, doc := range docs {
+		target := reflect.New(t).Interface()
+
+		content, positional := doc.Content, isYamlReadable(doc.Path)
+		if !positional {
+			data, err := codecFor(doc.Path).Decode(doc.Content)
+			if err != nil {
+				result.Fields = append(result.Fields, syntaxError(name, doc.Path, err).Fields...)
+				continue
+			}
+			delete(data, KeySchemaVersion)
+			if content, err = yaml.Marshal(data); err != nil {
+				continue
+			}
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+
+		err := decoder.Decode(target)
+		if err == nil {
+			continue
+		}
+
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			result.Fields = append(
+				result.Fields, FieldError{
+					File:    doc.Path,
+					Rule:    "syntax",
+					Message: err.Error(),
+				},
+			)
+			continue
+		}
+
+		index := indexYamlKeys(content)
+		for _, msg := range typeErr.Errors {
+			fe := decodeFieldError(doc.Path, index, msg)
+			if fe.Rule == "unknown_field" && fe.Path == KeySchemaVersion {
+				continue
+			}
+			if !positional {
+				fe.Line, fe.Column = 0, 0
+			}
+			result.Fields = append(result.Fields, fe)
+		}
+	}
+
+	if len(result.Fields) == 0 {
+		result.Fields = []FieldError{{Rule: "decode", Message: cause.Error()}}
+	}
+
+	return result
+}
+
+func syntaxError(
+	name, file string,
+	cause error,
+) *ValidationError {
+	fe := FieldError{File: file, Rule: "syntax", Message: cause.Error()}
+
+	var dErr *decodeError
+	if errors.As(cause, &dErr) {
+		fe.Line = dErr.Line
+		fe.Column = dErr.Column
+		fe.Message = dErr.Message
+	}
+
+	return &ValidationError{
+		Config: name,
+		Fields: []FieldError{fe},
+		err:    fmt.Errorf("%w: %v", ErrConfigParse, cause),
+	}
+}
+
+func errorFields(err error) []FieldError {
+	var vErr *ValidationError
+	if errors.As(err, &vErr) {
+		return vErr.Fields
+	}
+	return nil
+}
+
+func decodeFieldError(
+	file string,
+	index []keyLocation,
+	msg string,
+) FieldError {
+	fe := FieldError{File: file, Rule: "type", Message: msg}
+
+	match := yamlLineErrRe.FindStringSubmatch(msg)
+	if match == nil {
+		return fe
+	}
+
+	fe.Line, _ = strconv.Atoi(match[1])
+	fe.Message = match[2]
+
+	if unknown := yamlUnknownKeyRe.FindStringSubmatch(match[2]); unknown != nil {
+		fe.Rule = "unknown_field"
+		fe.Message = fmt.Sprintf("unknown key %q", unknown[1])
+		for _, loc := range index {
+			if loc.Line == fe.Line && strings.HasSuffix(loc.Path, unknown[1]) {
+				fe.Path = loc.Path
+				fe.Column = loc.Column
+				break
+			}
+		}
+		return fe
+	}
+
+	if mismatch := yamlTypeMismatchRe.FindStringSubmatch(match[2]); mismatch != nil {
+		fe.Message = fmt.Sprintf("expected %s, got %s", mismatch[2], strings.TrimPrefix(mismatch[1], "!!"))
+	}
+
+	for _, loc := range index {
+		if loc.Line == fe.Line {
+			fe.Path = loc.Path
+			fe.Column = loc.Column
+		}
+	}
+	return fe
+}
@@ -0,0 +1,107 @@
+package config_manager
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad_UnknownFieldReportsMergeFileLocation(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "diagunknown.yaml"), "name: base\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.diagunknown.yaml"), "count: 2\ncuont: 3\n")
+
+	_, err := m.load("diagunknown", reflect.TypeOf(TestConfig{}))
+	if !errors.Is(err, ErrConfigParse) {
+		t.Fatalf("expected ErrConfigParse, got %v", err)
+	}
+
+	fields := errorFields(err)
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field error, got %+v", fields)
+	}
+
+	fe := fields[0]
+	if fe.Rule != "unknown_field" || fe.Path != "cuont" {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+	if filepath.Base(fe.File) != "MERGE.diagunknown.yaml" || fe.Line != 2 || fe.Column != 1 {
+		t.Errorf("unexpected location: %s:%d:%d", fe.File, fe.Line, fe.Column)
+	}
+}
+
+func TestLoad_TypeMismatchReportsBaseFileLocation(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "diagtype.yaml"), "name: base\ncount: many\n")
+
+	_, err := m.load("diagtype", reflect.TypeOf(TestConfig{}))
+	fields := errorFields(err)
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field error, got %+v (%v)", fields, err)
+	}
+	if fields[0].Path != "count" || fields[0].Line != 2 || fields[0].Rule != "type" {
+		t.Errorf("unexpected field error: %+v", fields[0])
+	}
+}
+
+func TestValidate_LocatesFieldInMergeFile(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "diagrange.yaml"), "name: base\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.diagrange.yaml"), "\ncount: 500\n")
+
+	err := m.Register("diagrange", TestConfig{}, nil)
+
+	fields := errorFields(err)
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field error, got %+v (%v)", fields, err)
+	}
+
+	fe := fields[0]
+	if fe.Path != "count" || fe.Rule != "lte" {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+	if filepath.Base(fe.File) != "MERGE.diagrange.yaml" || fe.Line != 2 {
+		t.Errorf("unexpected location: %s:%d", fe.File, fe.Line)
+	}
+}
+
+func TestLoad_SyntaxErrorReportsLine(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "diagsyntax.yaml"), "name: base\ncount: [1\n")
+
+	_, err := m.load("diagsyntax", reflect.TypeOf(TestConfig{}))
+	if !errors.Is(err, ErrConfigParse) {
+		t.Fatalf("expected ErrConfigParse, got %v", err)
+	}
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "syntax" || fields[0].Line == 0 {
+		t.Errorf("unexpected syntax error report: %+v", fields)
+	}
+}
+
+func TestLoad_SyntaxErrorInMergeFileReportsLocation(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "diagmerge.yaml"), "name: base\ncount: 1\n")
+	mergeFile := filepath.Join(mergePath, "MERGE.diagmerge.yaml")
+	writeYamlFile(t, mergeFile, "count: [5\n")
+
+	_, err := m.load("diagmerge", reflect.TypeOf(TestConfig{}))
+	if !errors.Is(err, ErrConfigParse) {
+		t.Fatalf("expected ErrConfigParse, got %v", err)
+	}
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "syntax" || fields[0].File != mergeFile || fields[0].Line == 0 {
+		t.Errorf("unexpected syntax error report: %+v", fields)
+	}
+}
@@ -0,0 +1,108 @@
+package config_manager
+
+import (
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+type Change struct {
+	Path string     `json:"path"`
+	Type ChangeType `json:"type"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+func diffValues(
+	oldValue, newValue any,
+) ([]Change, error) {
+	oldData, err := toYamlMap(oldValue)
+	if err != nil {
+		return nil, err
+	}
+	newData, err := toYamlMap(newValue)
+	if err != nil {
+		return nil, err
+	}
+	return diffMaps(oldData, newData), nil
+}
+
+func toYamlMap(value any) (map[string]any, error) {
+	data := make(map[string]any)
+	if value == nil {
+		return data, nil
+	}
+
+	raw, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func diffMaps(
+	oldData, newData map[string]any,
+) []Change {
+	var changes []Change
+	collectChanges("", oldData, newData, &changes)
+	return changes
+}
+
+func collectChanges(
+	prefix string,
+	oldData, newData map[string]any,
+	changes *[]Change,
+) {
+	keys := make(map[string]struct{}, len(oldData)+len(newData))
+	for k := range oldData {
+		keys[k] = struct{}{}
+	}
+	for k := range newData {
+		keys[k] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		oldVal, inOld := oldData[k]
+		newVal, inNew := newData[k]
+
+		switch {
+		case !inOld:
+			*changes = append(*changes, Change{Path: path, Type: ChangeAdded, New: newVal})
+		case !inNew:
+			*changes = append(*changes, Change{Path: path, Type: ChangeRemoved, Old: oldVal})
+		default:
+			oldMap, isOldMap := oldVal.(map[string]any)
+			newMap, isNewMap := newVal.(map[string]any)
+			if isOldMap && isNewMap {
+				collectChanges(path, oldMap, newMap, changes)
+				continue
+			}
+			if !reflect.DeepEqual(oldVal, newVal) {
+				*changes = append(*changes, Change{Path: path, Type: ChangeModified, Old: oldVal, New: newVal})
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package config_manager
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type envOverride struct {
+	Variable string
+	Path     []string
+	Value    any
+}
+
+func (o envOverride) report(config string) EnvOverride {
+	return EnvOverride{
+		Config:   config,
+		Key:      strings.Join(o.Path, "."),
+		Variable: o.Variable,
+	}
+}
+
+func envPrefixFor(name string) string {
+	key := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+	return EnvPrefix + key + EnvKeySeparator
+}
+
+func (m *Manager) collectEnvOverrides(
+	name string,
+	targetType reflect.Type,
+) ([]envOverride, error) {
+	prefix := envPrefixFor(name)
+
+	var overrides []envOverride
+	for _, kv := range m.environ() {
+		variable, raw, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(variable, prefix) {
+			continue
+		}
+
+		segments := strings.Split(strings.TrimPrefix(variable, prefix), EnvKeySeparator)
+		path, fieldType, err := resolveYamlPath(targetType, segments)
+		if err != nil {
+			return nil, fmt.Errorf("env override %s: %w", variable, err)
+		}
+
+		typed := reflect.New(fieldType)
+		if err := yaml.Unmarshal([]byte(raw), typed.Interface()); err != nil {
+			return nil, fmt.Errorf("env override %s: cannot parse %q as %s: %w", variable, raw, fieldType, err)
+		}
+
+		overrides = append(
+			overrides, envOverride{
+				Variable: variable,
+				Path:     path,
+				Value:    typed.Elem().Interface(),
+			},
+		)
+	}
+
+	sort.Slice(
+		overrides, func(i, j int) bool {
+			return overrides[i].Variable < overrides[j].Variable
+		},
+	)
+
+	return overrides, nil
+}
+
+func applyEnvOverrides(
+	data map[string]any,
+	overrides []envOverride,
+) {
+	for _, o := range overrides {
+		node := data
+		for _, key := range o.Path[:len(o.Path)-1] {
+			child, ok := node[key].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[key] = child
+			}
+			node = child
+		}
+		node[o.Path[len(o.Path)-1]] = o.Value
+	}
+}
+
+func resolveYamlPath(
+	t reflect.Type,
+	segments []string,
+) ([]string, reflect.Type, error) {
+	path := make([]string, 0, len(segments))
+	current := t
+
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, nil, fmt.Errorf("empty key segment")
+		}
+
+		for current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+		if current.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("key %q is not a section", strings.Join(path, "."))
+		}
+
+		field, key, ok := findFieldByYamlKey(current, segment)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown key %q in %s", segment, current)
+		}
+
+		path = append(path, key)
+		current = field.Type
+	}
+
+	return path, current, nil
+}
+
+func findFieldByYamlKey(
+	t reflect.Type,
+	key string,
+) (reflect.StructField, string, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := yamlKeyOf(field)
+		if name == "-" {
+			continue
+		}
+		if strings.EqualFold(name, key) {
+			return field, name, true
+		}
+	}
+	return reflect.StructField{}, "", false
+}
+
+func yamlKeyOf(field reflect.StructField) string {
+	tag := field.Tag.Get("yaml")
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
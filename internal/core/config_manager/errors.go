@@ -2,4 +2,17 @@ package config_manager
 
 import "errors"
 
-var ErrPlaceholderCreated = errors.New("configuration file missing, placeholder created")
+var (
+	ErrPlaceholderCreated = errors.New("configuration file missing, placeholder created")
+	ErrConfigMissing      = errors.New("configuration file missing")
+	ErrConfigNotFound     = errors.New("configuration not registered")
+	ErrRevisionNotFound   = errors.New("configuration revision not found")
+	ErrConfigParse        = errors.New("configuration parse error")
+	ErrFormatConflict     = errors.New("configuration format conflict")
+	ErrSchemaVersion      = errors.New("configuration schema version error")
+	ErrSecretNotFound     = errors.New("configuration secret not found")
+	ErrBatchRejected      = errors.New("configuration batch rejected")
+	ErrSourceSpec         = errors.New("unsupported configuration source")
+	ErrSourcePath         = errors.New("configuration source path rejected")
+	ErrNotInContract      = errors.New("configuration name not in contract")
+)
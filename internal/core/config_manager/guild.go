@@ -0,0 +1,247 @@
+package config_manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+func (m *Manager) guildsDir() string {
+	return filepath.Clean(filepath.Join(m.pathMerge, DirGuilds))
+}
+
+func (m *Manager) guildMergePath(
+	name, guildID string,
+) string {
+	path, _ := m.locateFile(filepath.Join(m.guildsDir(), guildID), PrefixMerge+name)
+	return path
+}
+
+func (m *Manager) GetForGuild(
+	name, guildID string,
+) any {
+	m.mu.RLock()
+	meta, ok := m.registry[name]
+	if !ok {
+		m.mu.RUnlock()
+		m.log.Warn("attempted to get unregistered configuration", zap.String("config", name))
+		return nil
+	}
+	global := meta.CurrentValue
+	cached, hit := meta.guilds[guildID]
+	gen := meta.guildGen
+	m.mu.RUnlock()
+
+	if hit {
+		return cached
+	}
+	if global == nil || !snowflakeRe.MatchString(guildID) {
+		return global
+	}
+
+	value := m.resolveGuild(meta, guildID, global)
+
+	m.mu.Lock()
+	if meta.guildGen == gen {
+		if meta.guilds == nil {
+			meta.guilds = make(map[string]any)
+		}
+		meta.guilds[guildID] = value
+	}
+	m.mu.Unlock()
+
+	return value
+}
+
+func (m *Manager) resolveGuild(
+	meta *ConfigMeta,
+	guildID string,
+	global any,
+) any {
+	path, found := m.locateFile(filepath.Join(m.guildsDir(), guildID), PrefixMerge+meta.Name)
+	if len(found) == 0 {
+		return global
+	}
+
+	res, err := m.loadGuild(meta, guildID)
+	if err != nil {
+		m.log.Error(
+			"guild configuration rejected, using global value",
+			zap.String("config", meta.Name),
+			zap.String("guild_id", guildID),
+			zap.String("path", path),
+			zap.Error(err),
+		)
+		return global
+	}
+
+	m.log.Debug(
+		"guild configuration resolved",
+		zap.String("config", meta.Name),
+		zap.String("guild_id", guildID),
+	)
+	return res.Value
+}
+
+func (m *Manager) loadGuild(
+	meta *ConfigMeta,
+	guildID string,
+) (*loadResult, error) {
+	path, found := m.locateFile(filepath.Join(m.guildsDir(), guildID), PrefixMerge+meta.Name)
+	if len(found) > 1 {
+		return nil, formatConflictError(meta.Name, found)
+	}
+
+	guildData, includes, err := m.readConfigFile(path)
+	m.trackIncludes(meta.Name, path, includes)
+	if err != nil {
+		var dErr *decodeError
+		if errors.As(err, &dErr) {
+			return nil, syntaxError(meta.Name, path, err)
+		}
+		return nil, directiveValidationError(meta.Name, path, err)
+	}
+
+	baseData, err := m.readBase(meta.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := m.mergeLayers(meta.Name)
+	if err != nil {
+		return nil, err
+	}
+	layers = append(layers, mergeLayer{Path: path, Data: guildData})
+
+	res, err := m.compose(meta.Name, meta.StructType, baseData, layers)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.validate(meta.Name, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m *Manager) CheckGuilds(name string) []GuildCheck {
+	m.mu.RLock()
+	meta, ok := m.registry[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	entries, err := os.ReadDir(m.guildsDir())
+	if err != nil {
+		return nil
+	}
+
+	var checks []GuildCheck
+	for _, e := range entries {
+		if !e.IsDir() || !snowflakeRe.MatchString(e.Name()) {
+			continue
+		}
+		path, found := m.locateFile(filepath.Join(m.guildsDir(), e.Name()), PrefixMerge+name)
+		if len(found) == 0 {
+			continue
+		}
+
+		check := GuildCheck{GuildID: e.Name(), Path: path, Valid: true}
+		if _, err := m.loadGuild(meta, e.Name()); err != nil {
+			check.Valid = false
+			check.Error = err.Error()
+			check.Errors = errorFields(err)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (m *Manager) invalidateGuilds(
+	meta *ConfigMeta,
+	guildID string,
+) {
+	meta.guildGen++
+	if guildID == "" {
+		meta.guilds = nil
+		return
+	}
+	delete(meta.guilds, guildID)
+}
+
+func (m *Manager) handleGuildEvent(
+	ctx context.Context,
+	configName, guildID string,
+) {
+	m.mu.Lock()
+	meta, ok := m.registry[configName]
+	if ok {
+		m.invalidateGuilds(meta, guildID)
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	m.log.WithCtx(ctx).Info(
+		"guild configuration changed, cached value invalidated",
+		zap.String("config", configName),
+		zap.String("guild_id", guildID),
+	)
+}
+
+func (m *Manager) handleGuildDirEvent(
+	path string,
+	op string,
+) {
+	guildID := filepath.Base(path)
+	if !snowflakeRe.MatchString(guildID) {
+		return
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if err := m.watcher.Add(path); err != nil {
+			m.log.Error("failed to watch guild configuration directory", zap.String("path", path), zap.Error(err))
+		}
+	}
+
+	m.mu.Lock()
+	for _, meta := range m.registry {
+		m.invalidateGuilds(meta, guildID)
+	}
+	m.mu.Unlock()
+
+	m.log.Info(
+		"guild configuration directory changed",
+		zap.String("guild_id", guildID),
+		zap.String("operation", op),
+	)
+}
+
+func (m *Manager) watchGuildDirs() error {
+	dir := m.guildsDir()
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	if err := m.watcher.Add(dir); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !snowflakeRe.MatchString(e.Name()) {
+			continue
+		}
+		if err := m.watcher.Add(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package config_manager
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+const testGuildID = "1074340840000000000"
+
+func TestGetForGuild_MergesGuildOverlay(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "guilded.yaml"), "name: base\ncount: 5\nenabled: false")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.guilded.yaml"), "count: 10")
+	writeYamlFile(t, m.guildMergePath("guilded", testGuildID), "enabled: true")
+
+	if err := m.Register("guilded", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	cfg := m.GetForGuild("guilded", testGuildID).(TestConfig)
+	if !cfg.Enabled || cfg.Count != 10 || cfg.Name != "base" {
+		t.Errorf("unexpected guild config: %+v", cfg)
+	}
+
+	other := m.GetForGuild("guilded", "1129747578100000000").(TestConfig)
+	if other.Enabled {
+		t.Error("guild without overlay must receive the global config")
+	}
+
+	global := m.Get("guilded").(TestConfig)
+	if global.Enabled {
+		t.Error("guild overlay must not leak into the global config")
+	}
+}
+
+func TestGetForGuild_InvalidOverlayFallsBackToGlobal(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "guildbad.yaml"), "name: base\ncount: 5")
+	writeYamlFile(t, m.guildMergePath("guildbad", testGuildID), "count: 500")
+
+	if err := m.Register("guildbad", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	cfg := m.GetForGuild("guildbad", testGuildID).(TestConfig)
+	if cfg.Count != 5 {
+		t.Errorf("expected global count 5, got %d", cfg.Count)
+	}
+}
+
+func TestGetForGuild_InvalidatedOnChange(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "guildreload.yaml"), "name: base\ncount: 5")
+	guildPath := m.guildMergePath("guildreload", testGuildID)
+	writeYamlFile(t, guildPath, "count: 7")
+
+	if err := m.Register("guildreload", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	if cfg := m.GetForGuild("guildreload", testGuildID).(TestConfig); cfg.Count != 7 {
+		t.Fatalf("expected guild count 7, got %d", cfg.Count)
+	}
+
+	writeYamlFile(t, guildPath, "count: 8")
+	m.handleGuildEvent(context.Background(), "guildreload", testGuildID)
+
+	if cfg := m.GetForGuild("guildreload", testGuildID).(TestConfig); cfg.Count != 8 {
+		t.Errorf("expected guild count 8 after change, got %d", cfg.Count)
+	}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "guildreload.yaml"), "name: reloaded\ncount: 5")
+	m.reloadConfig(context.Background(), "guildreload")
+
+	if cfg := m.GetForGuild("guildreload", testGuildID).(TestConfig); cfg.Name != "reloaded" || cfg.Count != 8 {
+		t.Errorf("expected guild config rebuilt after global reload, got %+v", cfg)
+	}
+}
+
+func TestGetForGuild_RejectsInvalidGuildID(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "guildid.yaml"), "name: base\ncount: 5")
+	if err := m.Register("guildid", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	cfg := m.GetForGuild("guildid", "../..").(TestConfig)
+	if cfg.Name != "base" {
+		t.Errorf("expected global config for invalid guild id, got %+v", cfg)
+	}
+}
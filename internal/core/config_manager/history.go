@@ -0,0 +1,410 @@
+package config_manager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"DiscordBotAgent/pkg/ctxtrace"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+type Revision struct {
+	Number        int       `json:"number"`
+	Timestamp     time.Time `json:"timestamp"`
+	TraceID       string    `json:"trace_id"`
+	Source        string    `json:"source"`
+	Files         []string  `json:"files"`
+	Hash          string    `json:"hash"`
+	SchemaVersion int       `json:"schema_version,omitempty"`
+	Content       string    `json:"content,omitempty"`
+}
+
+func (m *Manager) historyDir(name string) string {
+	return filepath.Clean(filepath.Join(m.pathMerge, DirHistory, name))
+}
+
+func (m *Manager) recordRevision(
+	ctx context.Context,
+	name string,
+	res *loadResult,
+	source string,
+) {
+	if m.readOnly {
+		return
+	}
+
+	content, err := yaml.Marshal(res.Data)
+	if err != nil {
+		m.log.WithCtx(ctx).Error("failed to marshal revision", zap.String("config", name), zap.Error(err))
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	numbers, err := m.revisionNumbers(name)
+	if err != nil {
+		m.log.WithCtx(ctx).Error("failed to read revision history", zap.String("config", name), zap.Error(err))
+		return
+	}
+
+	next := 1
+	if len(numbers) > 0 {
+		last := numbers[len(numbers)-1]
+		prev, err := m.readRevision(name, last)
+		if err == nil && prev.Hash == hash {
+			m.log.WithCtx(ctx).Debug(
+				"configuration content unchanged, revision not recorded",
+				zap.String("config", name),
+				zap.Int("revision", last),
+			)
+			return
+		}
+		next = last + 1
+	}
+
+	rev := Revision{
+		Number:        next,
+		Timestamp:     time.Now(),
+		TraceID:       ctxtrace.Extract(ctx),
+		Source:        source,
+		Files:         res.Sources,
+		Hash:          hash,
+		SchemaVersion: m.SchemaVersion(name),
+		Content:       string(content),
+	}
+
+	if err := m.writeRevision(name, rev); err != nil {
+		m.log.WithCtx(ctx).Error("failed to persist revision", zap.String("config", name), zap.Error(err))
+		return
+	}
+
+	m.log.WithCtx(ctx).Info(
+		"configuration revision recorded",
+		zap.String("config", name),
+		zap.Int("revision", rev.Number),
+		zap.String("source", source),
+		zap.String("hash", hash),
+	)
+
+	for len(numbers) >= HistoryLimit {
+		stale := filepath.Join(m.historyDir(name), revisionFileName(numbers[0]))
+		if err := os.Remove(stale); err != nil {
+			m.log.WithCtx(ctx).Warn("failed to prune revision", zap.String("path", stale), zap.Error(err))
+		}
+		numbers = numbers[1:]
+	}
+}
+
+func (m *Manager) Revisions(name string) ([]Revision, error) {
+	if !m.isRegistered(name) {
+		return nil, ErrConfigNotFound
+	}
+
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	numbers, err := m.revisionNumbers(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Revision, 0, len(numbers))
+	for _, n := range numbers {
+		rev, err := m.readRevision(name, n)
+		if err != nil {
+			return nil, err
+		}
+		rev.Content = ""
+		result = append(result, rev)
+	}
+	return result, nil
+}
+
+func (m *Manager) Revision(
+	name string,
+	number int,
+) (Revision, error) {
+	if !m.isRegistered(name) {
+		return Revision{}, ErrConfigNotFound
+	}
+
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	return m.readRevision(name, number)
+}
+
+func (m *Manager) DiffRevisions(
+	name string,
+	from, to int,
+) ([]Change, error) {
+	fromData, err := m.revisionData(name, from)
+	if err != nil {
+		return nil, err
+	}
+	toData, err := m.revisionData(name, to)
+	if err != nil {
+		return nil, err
+	}
+	return diffMaps(fromData, toData), nil
+}
+
+func (m *Manager) Rollback(
+	ctx context.Context,
+	name string,
+	number int,
+) error {
+	m.mu.RLock()
+	meta, ok := m.registry[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return ErrConfigNotFound
+	}
+
+	if ctxtrace.Extract(ctx) == "" {
+		ctx = ctxtrace.WithCorrelationID(ctx, m.generateTraceID())
+	}
+
+	source := fmt.Sprintf("%s:%d", SourceRollback, number)
+
+	m.log.WithCtx(ctx).Info(
+		"rolling back configuration",
+		zap.String("config", name),
+		zap.Int("revision", number),
+	)
+
+	m.historyMu.Lock()
+	rev, err := m.readRevision(name, number)
+	m.historyMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(rev.Content), &data); err != nil {
+		return fmt.Errorf("parse revision %d: %w", number, err)
+	}
+
+	if rev.SchemaVersion > 0 {
+		data[KeySchemaVersion] = rev.SchemaVersion
+	}
+	data, _, err = m.migrate(name, filepath.Join(m.historyDir(name), revisionFileName(number)), data)
+	if err != nil {
+		m.rejectReload(ctx, meta, source, "migration", err)
+		return err
+	}
+
+	resolved, secrets, err := m.resolveSecrets(name, data)
+	if err != nil {
+		m.rejectReload(ctx, meta, source, "secrets", err)
+		return err
+	}
+
+	value, err := decodeStrict(resolved, meta.StructType)
+	if err != nil {
+		err = secrets.redactError(err)
+		m.rejectReload(ctx, meta, source, "load", err)
+		return err
+	}
+
+	res := &loadResult{
+		Value:   value,
+		Data:    data,
+		Sources: rev.Files,
+		secrets: secrets,
+	}
+
+	if err := m.applyConfig(ctx, meta, res, source); err != nil {
+		return err
+	}
+	return m.persistRollback(ctx, meta, data)
+}
+
+// persistRollback writes the restored values to the MERGE file so the next reload keeps them.
+func (m *Manager) persistRollback(
+	ctx context.Context,
+	meta *ConfigMeta,
+	data map[string]any,
+) error {
+	if m.readOnly {
+		return nil
+	}
+
+	baseData, err := m.readBase(meta.Name)
+	if err != nil {
+		return err
+	}
+	baseData, _, err = m.migrate(meta.Name, m.basePath(meta.Name), baseData)
+	if err != nil {
+		return err
+	}
+
+	overlay := mergeOverlay(baseData, data)
+	overrides, err := m.collectEnvOverrides(meta.Name, meta.StructType)
+	if err != nil {
+		return err
+	}
+	for _, o := range overrides {
+		deletePath(overlay, o.Path)
+	}
+	if plan := m.migrationPlanFor(meta.Name); plan != nil {
+		overlay[KeySchemaVersion] = plan.Version
+	}
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	path := m.mergePath(meta.Name)
+	content, err := codecFor(path).Encode(overlay)
+	if err != nil {
+		return fmt.Errorf("marshal merge file: %w", err)
+	}
+	if err := writeFileAtomic(path, content); err != nil {
+		return fmt.Errorf("write merge file: %w", err)
+	}
+
+	hash := m.sourceHash(meta.Name)
+	m.mu.Lock()
+	meta.sourceHash = hash
+	m.mu.Unlock()
+
+	m.log.WithCtx(ctx).Info(
+		"rolled back configuration written to merge file",
+		zap.String("config", meta.Name),
+		zap.String("path", path),
+	)
+	return nil
+}
+
+// mergeOverlay returns the keys of data whose values differ from base.
+func mergeOverlay(base, data map[string]any) map[string]any {
+	overlay := make(map[string]any)
+	for k, v := range data {
+		dataMap, isDataMap := v.(map[string]any)
+		baseMap, isBaseMap := base[k].(map[string]any)
+		switch {
+		case isDataMap && isBaseMap:
+			if child := mergeOverlay(baseMap, dataMap); len(child) > 0 {
+				overlay[k] = child
+			}
+		case !valuesEqual(v, base[k]):
+			overlay[k] = v
+		}
+	}
+	return overlay
+}
+
+func deletePath(
+	data map[string]any,
+	path []string,
+) {
+	if len(path) == 1 {
+		delete(data, path[0])
+		return
+	}
+	child, ok := data[path[0]].(map[string]any)
+	if !ok {
+		return
+	}
+	deletePath(child, path[1:])
+	if len(child) == 0 {
+		delete(data, path[0])
+	}
+}
+
+func (m *Manager) revisionData(
+	name string,
+	number int,
+) (map[string]any, error) {
+	rev, err := m.Revision(name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(rev.Content), &data); err != nil {
+		return nil, fmt.Errorf("parse revision %d: %w", number, err)
+	}
+	return data, nil
+}
+
+func (m *Manager) revisionNumbers(name string) ([]int, error) {
+	entries, err := os.ReadDir(m.historyDir(name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var numbers []int
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ExtensionRevision) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ExtensionRevision))
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+func (m *Manager) readRevision(
+	name string,
+	number int,
+) (Revision, error) {
+	path := filepath.Join(m.historyDir(name), revisionFileName(number))
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return Revision{}, ErrRevisionNotFound
+	}
+	if err != nil {
+		return Revision{}, err
+	}
+
+	var rev Revision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return Revision{}, fmt.Errorf("parse revision %d: %w", number, err)
+	}
+	return rev, nil
+}
+
+func (m *Manager) writeRevision(
+	name string,
+	rev Revision,
+) error {
+	dir := m.historyDir(name)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(rev, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, revisionFileName(rev.Number)), data, 0600)
+}
+
+func revisionFileName(number int) string {
+	return fmt.Sprintf("%06d%s", number, ExtensionRevision)
+}
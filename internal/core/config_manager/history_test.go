@@ -0,0 +1,166 @@
+package config_manager
+
+import (
+	"DiscordBotAgent/internal/core/zap_logger"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRevisions_RecordedOnRegisterAndReload(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "history.yaml")
+	writeYamlFile(t, path, "name: first\ncount: 1")
+
+	if err := m.Register("history", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	writeYamlFile(t, path, "name: second\ncount: 2")
+	m.reloadConfig(context.Background(), "history")
+
+	m.reloadConfig(context.Background(), "history")
+
+	revisions, err := m.Revisions("history")
+	if err != nil {
+		t.Fatalf("Revisions() error: %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("expected 2 revisions (unchanged reload skipped), got %d", len(revisions))
+	}
+	if revisions[0].Source != SourceRegister || revisions[1].Source != SourceReload {
+		t.Errorf("unexpected revision sources: %s, %s", revisions[0].Source, revisions[1].Source)
+	}
+	if revisions[1].Hash == "" || revisions[1].Content != "" {
+		t.Error("expected hash to be set and content omitted from list")
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "diffrev.yaml")
+	writeYamlFile(t, path, "name: first\ncount: 1")
+	_ = m.Register("diffrev", TestConfig{}, nil)
+
+	writeYamlFile(t, path, "name: first\ncount: 2\nenabled: true")
+	m.reloadConfig(context.Background(), "diffrev")
+
+	changes, err := m.DiffRevisions("diffrev", 1, 2)
+	if err != nil {
+		t.Fatalf("DiffRevisions() error: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Path != "count" || changes[0].Type != ChangeModified {
+		t.Errorf("unexpected first change: %+v", changes[0])
+	}
+	if changes[1].Path != "enabled" || changes[1].Type != ChangeAdded {
+		t.Errorf("unexpected second change: %+v", changes[1])
+	}
+}
+
+func TestRollback_RestoresRevision(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "rollback.yaml")
+	writeYamlFile(t, path, "name: first\ncount: 1")
+
+	updates := make(chan any, 3)
+	_ = m.Register(
+		"rollback",
+		TestConfig{},
+		func(
+			cfg any,
+			isValid bool,
+		) {
+			updates <- cfg
+		},
+	)
+	<-updates
+
+	writeYamlFile(t, path, "name: second\ncount: 2")
+	m.reloadConfig(context.Background(), "rollback")
+	<-updates
+
+	if err := m.Rollback(context.Background(), "rollback", 1); err != nil {
+		t.Fatalf("Rollback() error: %v", err)
+	}
+
+	cfg := (<-updates).(TestConfig)
+	if cfg.Name != "first" {
+		t.Errorf("expected restored name 'first', got '%s'", cfg.Name)
+	}
+
+	revisions, _ := m.Revisions("rollback")
+	last := revisions[len(revisions)-1]
+	if last.Source != SourceRollback+":1" {
+		t.Errorf("expected rollback revision source, got '%s'", last.Source)
+	}
+}
+
+func TestRollback_SurvivesPolling(t *testing.T) {
+	tmpDir := t.TempDir()
+	defaultPath := filepath.Join(tmpDir, "config_df")
+	mergePath := filepath.Join(tmpDir, "config_mrg")
+
+	logger, _ := zap_logger.New()
+	m, err := New(logger, defaultPath, mergePath, WithPollInterval(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "polledrollback.yaml")
+	writeYamlFile(t, path, "name: first\ncount: 1")
+	if err := m.Register("polledrollback", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	writeYamlFile(t, path, "name: second\ncount: 1")
+	m.reloadConfig(context.Background(), "polledrollback")
+
+	if err := m.Rollback(context.Background(), "polledrollback", 1); err != nil {
+		t.Fatalf("Rollback() error: %v", err)
+	}
+
+	time.Sleep(DebounceDuration + 300*time.Millisecond)
+
+	if cfg := m.Get("polledrollback").(TestConfig); cfg.Name != "first" {
+		t.Errorf("rollback was undone by the poller, got name %q", cfg.Name)
+	}
+
+	content, err := os.ReadFile(filepath.Join(mergePath, "MERGE.polledrollback.yaml"))
+	if err != nil {
+		t.Fatalf("merge file not written: %v", err)
+	}
+	if string(content) != "name: first\n" {
+		t.Errorf("expected only the rolled back key in the merge file, got %q", content)
+	}
+}
+
+func TestRollback_UnknownRevision(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "norev.yaml"), "name: first")
+	_ = m.Register("norev", TestConfig{}, nil)
+
+	err := m.Rollback(context.Background(), "norev", 42)
+	if !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("expected ErrRevisionNotFound, got %v", err)
+	}
+
+	err = m.Rollback(context.Background(), "unknown", 1)
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("expected ErrConfigNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+package config_manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+func (m *Manager) includeRoot(path string) string {
+	absMerge, _ := filepath.Abs(m.pathMerge)
+	absPath, _ := filepath.Abs(path)
+	if strings.HasPrefix(absPath, absMerge) {
+		return m.pathMerge
+	}
+	return m.pathDefault
+}
+
+func (m *Manager) resolveFileIncludes(
+	path string,
+	data map[string]any,
+) (map[string]any, []string, error) {
+	var includes []string
+
+	resolved, err := m.resolveIncludes(m.includeRoot(path), data, "", []string{path}, &includes)
+	if err != nil {
+		return nil, includes, err
+	}
+
+	result, ok := resolved.(map[string]any)
+	if !ok {
+		return nil, includes, &directiveError{Rule: "include", Message: "top-level include must resolve to an object"}
+	}
+	return result, includes, nil
+}
+
+func (m *Manager) resolveIncludes(
+	root string,
+	value any,
+	yamlPath string,
+	stack []string,
+	includes *[]string,
+) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		if raw, ok := v[DirectiveInclude]; ok {
+			return m.resolveIncludeNode(root, v, raw, yamlPath, stack, includes)
+		}
+		for k, child := range v {
+			resolved, err := m.resolveIncludes(root, child, joinYamlPath(yamlPath, k), stack, includes)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = resolved
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			resolved, err := m.resolveIncludes(root, item, fmt.Sprintf("%s[%d]", yamlPath, i), stack, includes)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+func (m *Manager) resolveIncludeNode(
+	root string,
+	node map[string]any,
+	raw any,
+	yamlPath string,
+	stack []string,
+	includes *[]string,
+) (any, error) {
+	includeErr := func(format string, args ...any) error {
+		return &directiveError{Path: joinYamlPath(yamlPath, DirectiveInclude), Rule: "include", Message: fmt.Sprintf(format, args...)}
+	}
+
+	var targets []string
+	switch r := raw.(type) {
+	case string:
+		targets = []string{r}
+	case []any:
+		for _, item := range r {
+			s, ok := item.(string)
+			if !ok {
+				return nil, includeErr("%s expects a path or a list of paths", DirectiveInclude)
+			}
+			targets = append(targets, s)
+		}
+	default:
+		return nil, includeErr("%s expects a path or a list of paths", DirectiveInclude)
+	}
+
+	var merged any
+	for _, target := range targets {
+		full := filepath.Clean(filepath.Join(root, target))
+		if err := m.checkConfigPath(full); err != nil {
+			return nil, includeErr("%v", err)
+		}
+
+		for _, p := range stack {
+			if p == full {
+				return nil, includeErr("include cycle: %s -> %s", strings.Join(stack, " -> "), full)
+			}
+		}
+
+		*includes = append(*includes, full)
+
+		content, err := os.ReadFile(full)
+		if err != nil {
+			return nil, includeErr("read %s: %v", target, err)
+		}
+		fragment, err := decodeFragment(full, content)
+		if err != nil {
+			return nil, includeErr("parse %s: %v", target, err)
+		}
+
+		chain := append(append([]string{}, stack...), full)
+		resolved, err := m.resolveIncludes(root, fragment, yamlPath, chain, includes)
+		if err != nil {
+			return nil, err
+		}
+
+		if merged, err = combineFragments(merged, resolved); err != nil {
+			return nil, includeErr("%s: %v", target, err)
+		}
+	}
+
+	rest := make(map[string]any, len(node)-1)
+	for k, v := range node {
+		if k != DirectiveInclude {
+			rest[k] = v
+		}
+	}
+	if len(rest) == 0 {
+		return merged, nil
+	}
+
+	resolvedRest, err := m.resolveIncludes(root, rest, yamlPath, stack, includes)
+	if err != nil {
+		return nil, err
+	}
+	rest = resolvedRest.(map[string]any)
+
+	switch base := merged.(type) {
+	case nil:
+		return rest, nil
+	case map[string]any:
+		if err := mergeInto(base, rest, yamlPath); err != nil {
+			return nil, err
+		}
+		return base, nil
+	case []any:
+		if hasDirective(rest) {
+			list, _, err := applyDirectives(base, rest, yamlPath)
+			return list, err
+		}
+	}
+	return nil, includeErr("local keys can only be combined with an included object or list directives")
+}
+
+func combineFragments(
+	current, next any,
+) (any, error) {
+	if current == nil {
+		return next, nil
+	}
+
+	switch c := current.(type) {
+	case map[string]any:
+		if n, ok := next.(map[string]any); ok {
+			return c, mergeInto(c, n, "")
+		}
+	case []any:
+		if n, ok := next.([]any); ok {
+			return append(c, n...), nil
+		}
+	}
+	return nil, fmt.Errorf("included fragments must all be objects or all be lists")
+}
+
+func decodeFragment(
+	path string,
+	content []byte,
+) (any, error) {
+	if !isYamlReadable(path) {
+		return codecFor(path).Decode(content)
+	}
+
+	var value any
+	if err := yaml.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func containsInclude(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		if _, ok := v[DirectiveInclude]; ok {
+			return true
+		}
+		for _, child := range v {
+			if containsInclude(child) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if containsInclude(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// trackIncludes replaces the fragments a config file includes, so a dropped $include stops triggering reloads.
+func (m *Manager) trackIncludes(
+	name, source string,
+	includes []string,
+) {
+	m.includeMu.Lock()
+	defer m.includeMu.Unlock()
+
+	if m.includes == nil {
+		m.includes = make(map[string]map[string][]string)
+		m.includeDirs = make(map[string]bool)
+	}
+	if len(includes) == 0 {
+		delete(m.includes[name], source)
+		return
+	}
+	if m.includes[name] == nil {
+		m.includes[name] = make(map[string][]string)
+	}
+	m.includes[name][source] = includes
+
+	for _, path := range includes {
+		dir := filepath.Dir(path)
+		if m.includeDirs[dir] || m.watcher == nil {
+			continue
+		}
+		if err := m.watcher.Add(dir); err != nil {
+			m.log.Warn("failed to watch include directory", zap.String("path", dir), zap.Error(err))
+			continue
+		}
+		m.includeDirs[dir] = true
+		m.log.Debug("watching include directory", zap.String("path", dir))
+	}
+}
+
+func (m *Manager) includesOf(name string) []string {
+	m.includeMu.Lock()
+	defer m.includeMu.Unlock()
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, includes := range m.includes[name] {
+		for _, path := range includes {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (m *Manager) includeDependents(path string) []string {
+	m.includeMu.Lock()
+	defer m.includeMu.Unlock()
+
+	var names []string
+	for name, sources := range m.includes {
+		for _, includes := range sources {
+			if slices.Contains(includes, path) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Manager) handleIncludeEvent(
+	path string,
+	op string,
+) bool {
+	dependents := m.includeDependents(path)
+	if len(dependents) == 0 {
+		return false
+	}
+
+	m.mu.Lock()
+	for _, name := range dependents {
+		if meta, ok := m.registry[name]; ok {
+			m.invalidateGuilds(meta, "")
+		}
+	}
+	m.mu.Unlock()
+
+	for _, name := range dependents {
+		m.scheduleReload(name, op, filepath.Base(path))
+	}
+	return true
+}
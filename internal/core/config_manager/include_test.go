@@ -0,0 +1,189 @@
+package config_manager
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type IncludeTestConfig struct {
+	Name  string   `yaml:"name" validate:"required"`
+	Roles []string `yaml:"roles"`
+	Inner struct {
+		Enabled bool `yaml:"enabled"`
+		Limit   int  `yaml:"limit"`
+	} `yaml:"inner"`
+}
+
+func TestLoad_IncludesSharedFragments(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "shared", "staff_roles.yaml"), "- \"1\"\n- \"2\"\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "shared", "inner.json"), `{"enabled": true, "limit": 3}`)
+	writeYamlFile(
+		t, filepath.Join(defaultPath, "included.yaml"), `
+name: base
+roles:
+  $include: shared/staff_roles.yaml
+  $append: ["3"]
+inner:
+  $include: shared/inner.json
+  limit: 5
+`,
+	)
+
+	result, err := m.loadAndMerge("included", reflect.TypeOf(IncludeTestConfig{}))
+	if err != nil {
+		t.Fatalf("loadAndMerge() error: %v", err)
+	}
+
+	cfg := result.(IncludeTestConfig)
+	if !reflect.DeepEqual(cfg.Roles, []string{"1", "2", "3"}) {
+		t.Errorf("unexpected roles: %v", cfg.Roles)
+	}
+	if !cfg.Inner.Enabled || cfg.Inner.Limit != 5 {
+		t.Errorf("unexpected inner: %+v", cfg.Inner)
+	}
+}
+
+func TestLoad_IncludeCycleDetected(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "shared", "a.yaml"), "$include: shared/b.yaml\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "shared", "b.yaml"), "$include: shared/a.yaml\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "cycle.yaml"), "name: base\ninner:\n  $include: shared/a.yaml\n")
+
+	_, err := m.load("cycle", reflect.TypeOf(IncludeTestConfig{}))
+
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "include" {
+		t.Fatalf("expected include error, got %v", err)
+	}
+}
+
+func TestLoad_IncludeOutsideConfigDirsRejected(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "..", "secret.yaml"), "enabled: true\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "escape.yaml"), "name: base\ninner:\n  $include: ../secret.yaml\n")
+
+	_, err := m.load("escape", reflect.TypeOf(IncludeTestConfig{}))
+
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "include" || fields[0].Path != "inner.$include" {
+		t.Fatalf("expected include error, got %v", err)
+	}
+}
+
+func TestWatcher_FragmentChangeReloadsDependents(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	fragment := filepath.Join(defaultPath, "shared", "limits.yaml")
+	writeYamlFile(t, fragment, "count: 1\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "fragmented.yaml"), "name: base\n$include: shared/limits.yaml\n")
+
+	updates := registerWithUpdates(t, m, "fragmented")
+
+	writeYamlFile(t, fragment, "count: 2\n")
+
+	select {
+	case cfg := <-updates:
+		if cfg.Count != 2 {
+			t.Errorf("expected count 2 from fragment, got %d", cfg.Count)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("editing a fragment did not reload the including config")
+	}
+}
+
+func TestLoad_IncludeCycleInMergeFileRejected(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "merged_cycle.yaml"), "name: base\ninner:\n  limit: 1\n")
+	if err := m.Register("merged_cycle", IncludeTestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	writeYamlFile(t, filepath.Join(mergePath, "shared", "a.yaml"), "$include: b.yaml\n")
+	writeYamlFile(t, filepath.Join(mergePath, "shared", "b.yaml"), "$include: a.yaml\n")
+	mergeFile := filepath.Join(mergePath, "MERGE.merged_cycle.yaml")
+	writeYamlFile(t, mergeFile, "inner:\n  $include: shared/a.yaml\n")
+
+	_, err := m.load("merged_cycle", reflect.TypeOf(IncludeTestConfig{}))
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "include" || fields[0].File != mergeFile {
+		t.Fatalf("expected include error in MERGE file, got %v", err)
+	}
+
+	m.reloadConfig(context.Background(), "merged_cycle")
+
+	info, _ := m.Info("merged_cycle")
+	if info.Rejected == nil {
+		t.Fatal("expected the reload to be rejected")
+	}
+	if cfg := m.Get("merged_cycle").(IncludeTestConfig); cfg.Inner.Limit != 1 {
+		t.Errorf("expected last good value to be kept, got %+v", cfg)
+	}
+}
+
+func TestLoad_DroppedIncludeIsUntracked(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	fragment := filepath.Join(defaultPath, "shared", "limits.yaml")
+	writeYamlFile(t, fragment, "count: 1\n")
+	path := filepath.Join(defaultPath, "dropped.yaml")
+	writeYamlFile(t, path, "name: base\n$include: shared/limits.yaml\n")
+	if err := m.Register("dropped", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if deps := m.includeDependents(fragment); len(deps) != 1 {
+		t.Fatalf("expected the fragment to be tracked, got %v", deps)
+	}
+
+	writeYamlFile(t, path, "name: base\ncount: 3\n")
+	m.reloadConfig(context.Background(), "dropped")
+
+	if deps := m.includeDependents(fragment); len(deps) != 0 {
+		t.Errorf("dropped include must not be tracked, got %v", deps)
+	}
+}
+
+func TestWatcher_IncludedConfigFileReloadsItself(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	shared := filepath.Join(defaultPath, "sharedbase.yaml")
+	writeYamlFile(t, shared, "name: shared\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "includer.yaml"), "$include: sharedbase.yaml\nname: includer\n")
+
+	sharedUpdates := registerWithUpdates(t, m, "sharedbase")
+	includerUpdates := registerWithUpdates(t, m, "includer")
+	time.Sleep(DebounceDuration + 100*time.Millisecond)
+	for len(sharedUpdates) > 0 || len(includerUpdates) > 0 {
+		select {
+		case <-sharedUpdates:
+		case <-includerUpdates:
+		}
+	}
+
+	writeYamlFile(t, shared, "name: shared\ncount: 2\n")
+
+	for name, updates := range map[string]chan TestConfig{"sharedbase": sharedUpdates, "includer": includerUpdates} {
+		select {
+		case cfg := <-updates:
+			if cfg.Count != 2 {
+				t.Errorf("%s: expected count 2, got %d", name, cfg.Count)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("%s was not reloaded", name)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package config_manager
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,48 +12,246 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+type loadResult struct {
+	Value        any
+	Data         map[string]any
+	Sources      []string
+	EnvOverrides []EnvOverride
+	overlay      []sourceDoc
+	migrated     []migratedDoc
+	secrets      *secretSet
+}
+
+type mergeLayer struct {
+	Path string
+	Data map[string]any
+}
+
+func (res *loadResult) diagnosableDocs() []sourceDoc {
+	docs := readSourceDocs(res.Sources, res.overlay)
+
+	result := make([]sourceDoc, 0, len(docs))
+	for _, doc := range docs {
+		if res.isMigrated(doc.Path) {
+			continue
+		}
+		if data, err := codecFor(doc.Path).Decode(doc.Content); err == nil && containsDirectives(data) {
+			continue
+		}
+		result = append(result, doc)
+	}
+	return result
+}
+
+func (res *loadResult) isMigrated(path string) bool {
+	for _, md := range res.migrated {
+		if md.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Manager) loadAndMerge(
 	name string,
 	targetType reflect.Type,
 ) (any, error) {
-	basePath := filepath.Clean(filepath.Join(m.pathDefault, name+ExtensionYaml))
-	mergePath := filepath.Clean(filepath.Join(m.pathMerge, PrefixMerge+name+ExtensionYaml))
+	res, err := m.load(name, targetType)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+func (m *Manager) basePath(name string) string {
+	path, _ := m.locateFile(m.pathDefault, name)
+	return path
+}
+
+func (m *Manager) mergePath(name string) string {
+	path, _ := m.locateFile(m.pathMerge, PrefixMerge+name)
+	return path
+}
+
+func (m *Manager) locateFile(
+	dir, stem string,
+) (string, []string) {
+	var found []string
+	for _, ext := range configExtensions {
+		path := filepath.Clean(filepath.Join(dir, stem+ext))
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+		}
+	}
+
+	if len(found) == 0 {
+		return filepath.Clean(filepath.Join(dir, stem+ExtensionYaml)), nil
+	}
+	return found[0], found
+}
+
+func (m *Manager) load(
+	name string,
+	targetType reflect.Type,
+) (*loadResult, error) {
+	baseData, err := m.readBase(name)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := m.mergeLayers(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.compose(name, targetType, baseData, layers)
+}
+
+func (m *Manager) mergeLayers(name string) ([]mergeLayer, error) {
+	mergeData, err := m.readMerge(name)
+	if err != nil || mergeData == nil {
+		return nil, err
+	}
+	return []mergeLayer{{Path: m.mergePath(name), Data: mergeData}}, nil
+}
+
+func (m *Manager) readBase(name string) (map[string]any, error) {
+	basePath, found := m.locateFile(m.pathDefault, name)
+	if len(found) > 1 {
+		return nil, formatConflictError(name, found)
+	}
 
 	m.log.Debug("reading base configuration file", zap.String("path", basePath))
-	baseData, err := m.readYamlToMap(basePath)
+	baseData, includes, err := m.readConfigFile(basePath)
+	m.trackIncludes(name, basePath, includes)
+	if err != nil {
+		return nil, configFileError(name, basePath, "read default config", err)
+	}
+	return baseData, nil
+}
+
+// configFileError reports syntax and include errors against the file that contains them.
+func configFileError(
+	name, path, action string,
+	err error,
+) error {
+	var dErr *decodeError
+	if errors.As(err, &dErr) {
+		return syntaxError(name, path, err)
+	}
+	var iErr *directiveError
+	if errors.As(err, &iErr) {
+		return directiveValidationError(name, path, err)
+	}
+	return fmt.Errorf("%s %s: %w", action, path, err)
+}
+
+func (m *Manager) readMerge(name string) (map[string]any, error) {
+	mergePath, found := m.locateFile(m.pathMerge, PrefixMerge+name)
+	if len(found) > 1 {
+		return nil, formatConflictError(name, found)
+	}
+
+	if len(found) == 0 {
+		m.log.Debug("no merge file found, using defaults", zap.String("config", name))
+		m.trackIncludes(name, mergePath, nil)
+		return nil, nil
+	}
+
+	m.log.Debug("reading merge configuration file", zap.String("path", mergePath))
+	mergeData, includes, err := m.readConfigFile(mergePath)
+	m.trackIncludes(name, mergePath, includes)
 	if err != nil {
-		return nil, fmt.Errorf("read default config: %w", err)
+		return nil, configFileError(name, mergePath, "read merge config", err)
+	}
+	return mergeData, nil
+}
+
+// readMergeRaw returns the MERGE file as written, with directives such as $include left in place.
+func (m *Manager) readMergeRaw(name string) (map[string]any, error) {
+	mergePath, found := m.locateFile(m.pathMerge, PrefixMerge+name)
+	if len(found) > 1 {
+		return nil, formatConflictError(name, found)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(mergePath)
+	if err != nil {
+		return nil, fmt.Errorf("read merge config %s: %w", mergePath, err)
+	}
+	data, err := codecFor(mergePath).Decode(content)
+	if err != nil {
+		return nil, configFileError(name, mergePath, "read merge config", err)
+	}
+	return data, nil
+}
+
+func (m *Manager) compose(
+	name string,
+	targetType reflect.Type,
+	baseData map[string]any,
+	layers []mergeLayer,
+	overlay ...sourceDoc,
+) (*loadResult, error) {
+	res := &loadResult{
+		Sources: []string{m.basePath(name)},
+		overlay: overlay,
 	}
 
-	if _, err := os.Stat(mergePath); err == nil {
-		m.log.Debug("reading merge configuration file", zap.String("path", mergePath))
-		mergeData, err := m.readYamlToMap(mergePath)
+	baseData, err := m.migrateDoc(name, m.basePath(name), baseData, res)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, layer := range layers {
+		layer.Data, err = m.migrateDoc(name, layer.Path, layer.Data, res)
 		if err != nil {
-			m.log.Warn(
-				"failed to read merge file, skipping overrides",
-				zap.String("path", mergePath),
-				zap.Error(err),
-			)
-		} else {
-			m.log.Debug("applying deep merge for configuration", zap.String("config", name))
-			m.deepMerge(baseData, mergeData)
+			return nil, err
 		}
-	} else {
-		m.log.Debug("no merge file found, using defaults", zap.String("config", name))
+
+		m.log.Debug(
+			"applying deep merge for configuration",
+			zap.String("config", name),
+			zap.String("path", layer.Path),
+		)
+		if err := m.deepMerge(baseData, layer.Data); err != nil {
+			return nil, directiveValidationError(name, layer.Path, err)
+		}
+		res.Sources = append(res.Sources, layer.Path)
 	}
 
-	finalYaml, err := yaml.Marshal(baseData)
+	overrides, err := m.collectEnvOverrides(name, targetType)
 	if err != nil {
-		return nil, fmt.Errorf("marshal merged data: %w", err)
+		return nil, err
 	}
 
-	resultStruct := reflect.New(targetType).Interface()
+	if len(overrides) > 0 {
+		m.log.Debug(
+			"applying environment overrides",
+			zap.String("config", name),
+			zap.Int("count", len(overrides)),
+		)
+		applyEnvOverrides(baseData, overrides)
+		for _, o := range overrides {
+			res.EnvOverrides = append(res.EnvOverrides, o.report(name))
+		}
+	}
 
-	decoder := yaml.NewDecoder(strings.NewReader(string(finalYaml)))
-	decoder.KnownFields(true)
+	resolved, secrets, err := m.resolveSecrets(name, baseData)
+	if err != nil {
+		var vErr *ValidationError
+		if errors.As(err, &vErr) {
+			m.locateFieldErrors(vErr, readSourceDocs(res.Sources, res.overlay), res.EnvOverrides)
+		}
+		return nil, err
+	}
+	res.secrets = secrets
 
-	if err := decoder.Decode(resultStruct); err != nil {
-		return nil, fmt.Errorf("strict unmarshal error (check for unknown fields): %w", err)
+	value, err := decodeStrict(resolved, targetType)
+	if err != nil {
+		return nil, diagnoseDecode(name, targetType, res.diagnosableDocs(), secrets.redactError(err))
 	}
 
 	m.log.Debug(
@@ -61,44 +260,65 @@ func (m *Manager) loadAndMerge(
 		zap.String("type", targetType.String()),
 	)
 
+	res.Value = value
+	res.Data = baseData
+	return res, nil
+}
+
+func decodeStrict(
+	data map[string]any,
+	targetType reflect.Type,
+) (any, error) {
+	finalYaml, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal merged data: %w", err)
+	}
+
+	resultStruct := reflect.New(targetType).Interface()
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(finalYaml)))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(resultStruct); err != nil {
+		return nil, fmt.Errorf("%w: strict unmarshal error (check for unknown fields): %v", ErrConfigParse, err)
+	}
+
 	return reflect.ValueOf(resultStruct).Elem().Interface(), nil
 }
 
-func (m *Manager) readYamlToMap(path string) (map[string]any, error) {
+func (m *Manager) readConfigFile(path string) (map[string]any, []string, error) {
 	cleanPath := filepath.Clean(path)
 
-	absDefault, _ := filepath.Abs(m.pathDefault)
-	absMerge, _ := filepath.Abs(m.pathMerge)
-	absPath, _ := filepath.Abs(cleanPath)
-
-	if !strings.HasPrefix(absPath, absDefault) && !strings.HasPrefix(absPath, absMerge) {
-		return nil, fmt.Errorf("path outside config directories: %s", path)
+	if err := m.checkConfigPath(cleanPath); err != nil {
+		return nil, nil, err
 	}
 
 	file, err := os.ReadFile(cleanPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	data := make(map[string]any)
-	if err := yaml.Unmarshal(file, &data); err != nil {
-		return nil, err
+	data, err := codecFor(cleanPath).Decode(file)
+	if err != nil {
+		return nil, nil, err
 	}
-	return data, nil
+
+	return m.resolveFileIncludes(cleanPath, data)
 }
 
-func (m *Manager) deepMerge(base, merge map[string]any) {
-	for k, v := range merge {
-		if baseVal, ok := base[k]; ok {
-			baseMap, isBaseMap := baseVal.(map[string]any)
-			mergeMap, isMergeMap := v.(map[string]any)
-			if isBaseMap && isMergeMap {
-				m.deepMerge(baseMap, mergeMap)
-				continue
-			}
-		}
-		base[k] = v
+func (m *Manager) checkConfigPath(path string) error {
+	absDefault, _ := filepath.Abs(m.pathDefault)
+	absMerge, _ := filepath.Abs(m.pathMerge)
+	absPath, _ := filepath.Abs(path)
+
+	if !strings.HasPrefix(absPath, absDefault) && !strings.HasPrefix(absPath, absMerge) {
+		return fmt.Errorf("path outside config directories: %s", path)
 	}
+	return nil
+}
+
+func (m *Manager) deepMerge(base, merge map[string]any) error {
+	return mergeInto(base, merge, "")
 }
 
 func (m *Manager) ScanUsage() ScanReport {
@@ -106,27 +326,63 @@ func (m *Manager) ScanUsage() ScanReport {
 	defer m.mu.RUnlock()
 
 	report := ScanReport{}
-	files, err := os.ReadDir(m.pathDefault)
+	for _, meta := range m.registry {
+		report.EnvOverrides = append(report.EnvOverrides, meta.EnvOverrides...)
+		report.Secrets = append(report.Secrets, meta.secrets.refs()...)
+	}
+
+	files, err := m.scanDir(m.pathDefault, "")
 	if err != nil {
 		m.log.Error("failed to scan default config directory", zap.Error(err))
 		return report
 	}
+	report.Files = append(report.Files, files...)
 
-	for _, f := range files {
-		if f.IsDir() || !strings.HasSuffix(f.Name(), ExtensionYaml) {
+	mergeDirs := []string{m.pathMerge}
+	if entries, err := os.ReadDir(m.guildsDir()); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				mergeDirs = append(mergeDirs, filepath.Join(m.guildsDir(), e.Name()))
+			}
+		}
+	}
+
+	for _, dir := range mergeDirs {
+		files, err := m.scanDir(dir, PrefixMerge)
+		if err != nil && !os.IsNotExist(err) {
+			m.log.Error("failed to scan merge config directory", zap.String("path", dir), zap.Error(err))
+		}
+		report.Files = append(report.Files, files...)
+	}
+
+	return report
+}
+
+func (m *Manager) scanDir(
+	dir, prefix string,
+) ([]FileStatus, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []FileStatus
+	for _, f := range entries {
+		if f.IsDir() {
 			continue
 		}
 
-		name := strings.TrimSuffix(f.Name(), ExtensionYaml)
-		_, isUsed := m.registry[name]
+		stem, ok := configStem(f.Name())
+		if !ok || !strings.HasPrefix(stem, prefix) {
+			continue
+		}
 
-		report.Files = append(
-			report.Files, FileStatus{
-				Path:   filepath.Join(m.pathDefault, f.Name()),
-				IsUsed: isUsed,
+		files = append(
+			files, FileStatus{
+				Path:   filepath.Join(dir, f.Name()),
+				IsUsed: m.claimed[strings.TrimPrefix(stem, prefix)] != nil,
 			},
 		)
 	}
-
-	return report
+	return files, nil
 }
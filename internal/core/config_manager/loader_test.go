@@ -162,3 +162,265 @@ func TestScanUsage_DetectsUnusedFiles(t *testing.T) {
 		t.Error("used file not marked as used")
 	}
 }
+
+type NestedTestConfig struct {
+	Name    string          `yaml:"name" validate:"required"`
+	MaxLogs int             `yaml:"max_logs"`
+	Details NestedTestInner `yaml:"logDetails"`
+}
+
+type NestedTestInner struct {
+	Guild   bool `yaml:"guild"`
+	Content bool `yaml:"content"`
+}
+
+func TestLoadAndMerge_EnvOverridesAfterMerge(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	baseYaml := `
+name: "base"
+max_logs: 10
+logDetails:
+  guild: true
+  content: true
+`
+	mergeYaml := `
+max_logs: 20
+`
+	writeYamlFile(t, filepath.Join(defaultPath, "system.env.test.yaml"), baseYaml)
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.system.env.test.yaml"), mergeYaml)
+
+	m.environ = func() []string {
+		return []string{
+			"DBA_SYSTEM_ENV_TEST__LOGDETAILS__GUILD=false",
+			"DBA_SYSTEM_ENV_TEST__MAX_LOGS=30",
+			"DBA_OTHER__NAME=ignored",
+		}
+	}
+
+	res, err := m.load("system.env.test", reflect.TypeOf(NestedTestConfig{}))
+	if err != nil {
+		t.Fatalf("load() error: %v", err)
+	}
+
+	cfg := res.Value.(NestedTestConfig)
+	if cfg.Details.Guild {
+		t.Error("expected logDetails.guild to be overridden to false")
+	}
+	if !cfg.Details.Content {
+		t.Error("expected logDetails.content to remain true")
+	}
+	if cfg.MaxLogs != 30 {
+		t.Errorf("expected max_logs 30, got %d", cfg.MaxLogs)
+	}
+	if len(res.EnvOverrides) != 2 {
+		t.Fatalf("expected 2 env overrides, got %d", len(res.EnvOverrides))
+	}
+	if res.EnvOverrides[0].Key != "logDetails.guild" {
+		t.Errorf("expected key 'logDetails.guild', got '%s'", res.EnvOverrides[0].Key)
+	}
+}
+
+func TestLoadAndMerge_EnvOverrideUnknownKey(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "envunknown.yaml"), "name: test")
+	m.environ = func() []string {
+		return []string{"DBA_ENVUNKNOWN__MISSING=1"}
+	}
+
+	if _, err := m.loadAndMerge("envunknown", reflect.TypeOf(TestConfig{})); err == nil {
+		t.Error("expected error for unknown env override key")
+	}
+}
+
+func TestLoadAndMerge_EnvOverrideTypeMismatch(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "envtype.yaml"), "name: test")
+	m.environ = func() []string {
+		return []string{"DBA_ENVTYPE__COUNT=many"}
+	}
+
+	if _, err := m.loadAndMerge("envtype", reflect.TypeOf(TestConfig{})); err == nil {
+		t.Error("expected error for non-numeric env override")
+	}
+}
+
+func TestDeepMerge_AppendPrependRemove(t *testing.T) {
+	logger, _ := zap_logger.New()
+	m := &Manager{log: logger}
+
+	base := map[string]any{
+		"channels": []any{1, 2, 3},
+	}
+	merge := map[string]any{
+		"channels": map[string]any{
+			"$remove":  []any{2},
+			"$prepend": []any{0},
+			"$append":  []any{4},
+		},
+	}
+
+	if err := m.deepMerge(base, merge); err != nil {
+		t.Fatalf("deepMerge() error: %v", err)
+	}
+
+	expected := []any{0, 1, 3, 4}
+	if !reflect.DeepEqual(base["channels"], expected) {
+		t.Errorf("expected %v, got %v", expected, base["channels"])
+	}
+}
+
+func TestDeepMerge_AppendToMissingList(t *testing.T) {
+	logger, _ := zap_logger.New()
+	m := &Manager{log: logger}
+
+	base := map[string]any{}
+	merge := map[string]any{
+		"roles": map[string]any{"$append": []any{"admin"}},
+	}
+
+	if err := m.deepMerge(base, merge); err != nil {
+		t.Fatalf("deepMerge() error: %v", err)
+	}
+
+	if !reflect.DeepEqual(base["roles"], []any{"admin"}) {
+		t.Errorf("expected [admin], got %v", base["roles"])
+	}
+}
+
+func TestDeepMerge_MergeByKey(t *testing.T) {
+	logger, _ := zap_logger.New()
+	m := &Manager{log: logger}
+
+	base := map[string]any{
+		"rules": []any{
+			map[string]any{"id": "spam", "enabled": true, "limit": 5},
+			map[string]any{"id": "links", "enabled": true},
+			map[string]any{"id": "caps", "enabled": true},
+		},
+	}
+	merge := map[string]any{
+		"rules": map[string]any{
+			"$key": "id",
+			"$items": []any{
+				map[string]any{"id": "spam", "limit": 10},
+				map[string]any{"id": "links", "$delete": true},
+				map[string]any{"id": "invites", "enabled": false},
+			},
+		},
+	}
+
+	if err := m.deepMerge(base, merge); err != nil {
+		t.Fatalf("deepMerge() error: %v", err)
+	}
+
+	expected := []any{
+		map[string]any{"id": "spam", "enabled": true, "limit": 10},
+		map[string]any{"id": "caps", "enabled": true},
+		map[string]any{"id": "invites", "enabled": false},
+	}
+	if !reflect.DeepEqual(base["rules"], expected) {
+		t.Errorf("expected %v, got %v", expected, base["rules"])
+	}
+}
+
+func TestDeepMerge_RemoveByKey(t *testing.T) {
+	logger, _ := zap_logger.New()
+	m := &Manager{log: logger}
+
+	base := map[string]any{
+		"rules": []any{
+			map[string]any{"id": "spam"},
+			map[string]any{"id": "links"},
+		},
+	}
+	merge := map[string]any{
+		"rules": map[string]any{
+			"$key":    "id",
+			"$remove": []any{"spam"},
+		},
+	}
+
+	if err := m.deepMerge(base, merge); err != nil {
+		t.Fatalf("deepMerge() error: %v", err)
+	}
+
+	expected := []any{map[string]any{"id": "links"}}
+	if !reflect.DeepEqual(base["rules"], expected) {
+		t.Errorf("expected %v, got %v", expected, base["rules"])
+	}
+}
+
+func TestDeepMerge_ReplaceAndDelete(t *testing.T) {
+	logger, _ := zap_logger.New()
+	m := &Manager{log: logger}
+
+	base := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080},
+		"legacy": "value",
+	}
+	merge := map[string]any{
+		"server": map[string]any{"$replace": map[string]any{"port": 9090}},
+		"legacy": map[string]any{"$delete": true},
+	}
+
+	if err := m.deepMerge(base, merge); err != nil {
+		t.Fatalf("deepMerge() error: %v", err)
+	}
+
+	if !reflect.DeepEqual(base["server"], map[string]any{"port": 9090}) {
+		t.Errorf("expected server to be replaced, got %v", base["server"])
+	}
+	if _, ok := base["legacy"]; ok {
+		t.Error("expected legacy key to be deleted")
+	}
+}
+
+func TestDeepMerge_InvalidDirectives(t *testing.T) {
+	logger, _ := zap_logger.New()
+	m := &Manager{log: logger}
+
+	cases := map[string]map[string]any{
+		"unknown directive": {"list": map[string]any{"$insert": []any{1}}},
+		"list on scalar":    {"name": map[string]any{"$append": []any{1}}},
+		"items without key": {"list": map[string]any{"$items": []any{}}},
+		"replace combined":  {"list": map[string]any{"$replace": []any{}, "$append": []any{1}}},
+		"top-level":         {"$append": []any{1}},
+	}
+
+	for name, merge := range cases {
+		base := map[string]any{"name": "x", "list": []any{1}}
+		if err := m.deepMerge(base, merge); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadAndMerge_MergeDirectivesFromFile(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "directives.yaml"), "name: base\nmax_logs: 1\nlogDetails:\n  guild: true\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.directives.yaml"), "logDetails:\n  guild:\n    $delete: true\n")
+
+	result, err := m.loadAndMerge("directives", reflect.TypeOf(NestedTestConfig{}))
+	if err != nil {
+		t.Fatalf("loadAndMerge() error: %v", err)
+	}
+	if result.(NestedTestConfig).Details.Guild {
+		t.Error("expected guild to be reset by $delete")
+	}
+
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.directives.yaml"), "max_logs:\n  $append: [1]\n")
+
+	_, err = m.load("directives", reflect.TypeOf(NestedTestConfig{}))
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "merge_directive" || fields[0].Path != "max_logs" {
+		t.Errorf("expected merge_directive error for max_logs, got %+v", fields)
+	}
+}
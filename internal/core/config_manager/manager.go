@@ -1,14 +1,16 @@
 package config_manager
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"DiscordBotAgent/internal/core/zap_logger"
+	"DiscordBotAgent/pkg/ctxtrace"
 
 	"github.com/fsnotify/fsnotify"
 	"go.uber.org/zap"
@@ -16,9 +18,17 @@ import (
 
 type Manager struct {
 	mu          sync.RWMutex
+	historyMu   sync.Mutex
+	writeMu     sync.Mutex
+	migrationMu sync.RWMutex
+	includeMu   sync.Mutex
+	auditMu     sync.Mutex
 	log         *zap_logger.Logger
 	validator   *ConfigValidator
 	registry    map[string]*ConfigMeta
+	migrations  map[string]*migrationPlan
+	includes    map[string]map[string][]string
+	includeDirs map[string]bool
 	timers      map[string]*time.Timer
 	watcher     *fsnotify.Watcher
 	pathDefault string
@@ -27,32 +37,88 @@ type Manager struct {
 		time.Duration,
 		func(),
 	) *time.Timer
+	environ            func() []string
+	pollInterval       time.Duration
+	migrationWriteBack bool
+	secrets            SecretResolver
+	source             ConfigSource
+	sourceInterval     time.Duration
+	sourceVersion      string
+	sourceMu           sync.Mutex
+	readOnly           bool
+	batchWindow        time.Duration
+	batchPending       map[string]bool
+	batchHeld          map[string]bool
+	batchTimer         *time.Timer
+	claimed            map[string]*ConfigMeta
+	origins            map[string]writeOrigin
+	rewatching         map[string]bool
+	forcedReloads      map[string]bool
+	done               chan struct{}
 }
 
 func New(
 	log *zap_logger.Logger,
 	pathDefault, pathMerge string,
+	opts ...ManagerOption,
 ) (*Manager, error) {
+	m := &Manager{
+		log:           log,
+		validator:     NewValidator(log),
+		registry:      make(map[string]*ConfigMeta),
+		migrations:    make(map[string]*migrationPlan),
+		includes:      make(map[string]map[string][]string),
+		includeDirs:   make(map[string]bool),
+		timers:        make(map[string]*time.Timer),
+		pathDefault:   pathDefault,
+		pathMerge:     pathMerge,
+		afterFunc:     time.AfterFunc,
+		environ:       os.Environ,
+		rewatching:    make(map[string]bool),
+		forcedReloads: make(map[string]bool),
+		claimed:       make(map[string]*ConfigMeta),
+		origins:       make(map[string]writeOrigin),
+		batchPending:  make(map[string]bool),
+		batchHeld:     make(map[string]bool),
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.readOnly {
+		return m, nil
+	}
+
 	for _, p := range []string{pathDefault, pathMerge} {
 		if err := os.MkdirAll(p, 0750); err != nil {
 			return nil, fmt.Errorf("failed to create config directory %s: %w", p, err)
 		}
 	}
 
-	m := &Manager{
-		log:         log,
-		validator:   NewValidator(log),
-		registry:    make(map[string]*ConfigMeta),
-		timers:      make(map[string]*time.Timer),
-		pathDefault: pathDefault,
-		pathMerge:   pathMerge,
-		afterFunc:   time.AfterFunc,
+	if m.source != nil {
+		ctx := ctxtrace.WithCorrelationID(context.Background(), m.generateTraceID())
+		if err := m.syncSource(ctx); err != nil {
+			m.log.WithCtx(ctx).Error(
+				"initial config source sync failed, using local copy",
+				zap.String("source", m.source.Name()),
+				zap.Error(err),
+			)
+		}
 	}
 
 	if err := m.initWatcher(); err != nil {
 		return nil, fmt.Errorf("failed to init config watcher: %w", err)
 	}
 
+	if m.source != nil {
+		go m.sourceLoop()
+	}
+
+	if m.pollInterval > 0 {
+		go m.pollLoop()
+	}
+
 	return m, nil
 }
 
@@ -60,6 +126,7 @@ func (m *Manager) Register(
 	name string,
 	template any,
 	callback UpdateCallback,
+	opts ...Option,
 ) error {
 	m.log.Info("registering configuration", zap.String("config", name))
 
@@ -71,52 +138,107 @@ func (m *Manager) Register(
 		t = t.Elem()
 	}
 
-	basePath := filepath.Clean(filepath.Join(m.pathDefault, name+ExtensionYaml))
+	meta := newConfigMeta(name, t, template, callback, opts)
+	m.setMigrationPlan(name, meta.migration)
+	m.claimed[name] = meta
+
+	if !m.readOnly {
+		m.writeSchema(name, t)
+	}
+
+	basePath := m.basePath(name)
 
 	info, err := os.Stat(basePath)
 	if os.IsNotExist(err) || (err == nil && info.Size() == 0) {
-		if err := m.createPlaceholder(basePath, template); err != nil {
+		if m.readOnly {
+			return ErrConfigMissing
+		}
+		if err := m.createPlaceholder(name, basePath, template); err != nil {
 			return fmt.Errorf("failed to create placeholder for %s: %w", name, err)
 		}
 
-		m.registry[name] = &ConfigMeta{
-			Name:       name,
-			StructType: t,
-			OnUpdate:   callback,
-			IsUsed:     true,
-		}
+		m.registry[name] = meta
 
 		return ErrPlaceholderCreated
 	}
 
-	cfg, err := m.loadAndMerge(name, t)
+	ctx := ctxtrace.WithCorrelationID(context.Background(), m.generateTraceID())
+
+	res, err := m.load(name, t)
 	if err != nil {
+		m.recordRejection(ctx, name, SourceRegister, "load", err)
+		m.keepRejected(ctx, meta, err)
 		return err
 	}
+	cfg := res.Value
 
-	if err := m.validator.Validate(name, cfg); err != nil {
+	if err := m.validate(name, res); err != nil {
+		m.recordRejection(ctx, name, SourceRegister, "validation", err)
+		m.keepRejected(ctx, meta, err)
 		return err
 	}
 
-	meta := &ConfigMeta{
-		Name:         name,
-		StructType:   t,
-		OnUpdate:     callback,
-		IsUsed:       true,
-		CurrentValue: cfg,
-	}
+	meta.CurrentValue = cfg
+	meta.EnvOverrides = res.EnvOverrides
+	meta.secrets = res.secrets
+	meta.sourceHash = m.sourceHash(name)
 
 	m.registry[name] = meta
 
-	if callback != nil {
+	m.persistMigrations(ctx, name, res)
+	m.recordRevision(ctx, name, res, SourceRegister)
+	m.recordAudit(ctx, AuditEntry{Config: name, Source: SourceRegister, Outcome: AuditApplied})
+
+	if meta.OnUpdate != nil || meta.OnChange != nil {
 		m.log.Debug("executing initial configuration callback", zap.String("config", name))
-		callback(cfg, true)
+		meta.notify(ctx, UpdateEvent{Config: cfg, Valid: true})
 	}
 
 	m.log.Info("configuration registered and active", zap.String("config", name))
 	return nil
 }
 
+// keepRejected registers a config without a value; callers must hold m.mu.
+func (m *Manager) keepRejected(
+	ctx context.Context,
+	meta *ConfigMeta,
+	err error,
+) {
+	if !meta.registerOnError || m.readOnly {
+		return
+	}
+
+	meta.Rejected = &RejectedRevision{
+		TraceID:    ctxtrace.Extract(ctx),
+		Error:      err.Error(),
+		Errors:     errorFields(err),
+		RejectedAt: time.Now(),
+	}
+	meta.sourceHash = m.sourceHash(meta.Name)
+	m.registry[meta.Name] = meta
+}
+
+func newConfigMeta(
+	name string,
+	t reflect.Type,
+	template any,
+	callback UpdateCallback,
+	opts []Option,
+) *ConfigMeta {
+	meta := &ConfigMeta{
+		Name:       name,
+		StructType: t,
+		template:   template,
+		OnUpdate:   callback,
+		IsUsed:     true,
+		Policy:     PolicyKeepLastGood,
+	}
+	for _, opt := range opts {
+		opt(meta)
+	}
+	return meta
+}
+
 func (m *Manager) Get(name string) any {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -130,28 +252,105 @@ func (m *Manager) Get(name string) any {
 	return meta.CurrentValue
 }
 
+func (m *Manager) Validations() ValidationRegistry {
+	return m.validator
+}
+
+func (m *Manager) isRegistered(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.registry[name]
+	return ok
+}
+
+func (m *Manager) Info(name string) (ConfigInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	meta, ok := m.registry[name]
+	if !ok {
+		return ConfigInfo{}, false
+	}
+
+	return meta.info(), true
+}
+
+func (m *Manager) GetAllConfigs() []ConfigInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]ConfigInfo, 0, len(m.registry))
+	for _, meta := range m.registry {
+		result = append(result, meta.info())
+	}
+
+	sort.Slice(
+		result, func(i, j int) bool {
+			return result[i].Name < result[j].Name
+		},
+	)
+
+	return result
+}
+
 func (m *Manager) PrintReport() {
 	m.log.Info("starting configuration usage audit")
 	report := m.ScanUsage()
+	audit := m.Audit()
 
-	unusedCount := 0
 	for _, f := range report.Files {
-		if !f.IsUsed {
-			m.log.Warn("unused configuration file detected", zap.String("path", f.Path))
-			unusedCount++
-		} else {
+		if f.IsUsed {
 			m.log.Debug("active configuration file", zap.String("path", f.Path))
 		}
 	}
 
+	for _, f := range audit.Findings {
+		m.log.Warn(
+			"configuration audit finding",
+			zap.String("kind", string(f.Kind)),
+			zap.String("config", f.Config),
+			zap.String("path", f.File),
+			zap.String("key", f.Key),
+			zap.Int("line", f.Line),
+			zap.String("message", f.Message),
+		)
+	}
+
+	for _, o := range report.EnvOverrides {
+		m.log.Info(
+			"configuration key overridden from environment",
+			zap.String("config", o.Config),
+			zap.String("key", o.Key),
+			zap.String("variable", o.Variable),
+		)
+	}
+
+	for _, s := range report.Secrets {
+		m.log.Info(
+			"configuration key resolved from secret",
+			zap.String("config", s.Config),
+			zap.String("key", s.Key),
+			zap.String("value", placeholderFor(s.Secret)),
+		)
+	}
+
 	m.log.Info(
 		"configuration audit finished",
 		zap.Int("total_files", len(report.Files)),
-		zap.Int("unused_files", unusedCount),
+		zap.Int("unused_files", audit.Count(AuditUnusedFile)),
+		zap.Int("findings", len(audit.Findings)),
+		zap.Int("env_overrides", len(report.EnvOverrides)),
+		zap.Int("secrets", len(report.Secrets)),
 	)
 }
 
 func (m *Manager) Close() error {
+	select {
+	case <-m.done:
+	default:
+		close(m.done)
+	}
+
 	if m.watcher != nil {
 		err := m.watcher.Close()
 		if err != nil {
@@ -3,9 +3,11 @@ package config_manager
 
 import (
 	"DiscordBotAgent/internal/core/zap_logger"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 type TestConfig struct {
@@ -114,6 +116,54 @@ count: 50
 	}
 }
 
+func TestRegister_RegisterOnErrorReloadsFixedFile(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "registeronerror.yaml")
+	writeYamlFile(t, path, "count: 5")
+
+	updates := make(chan bool, 2)
+	err := m.Register(
+		"registeronerror",
+		TestConfig{},
+		func(
+			cfg any,
+			isValid bool,
+		) {
+			updates <- isValid
+		},
+		WithRegisterOnError(),
+	)
+	if err == nil {
+		t.Fatal("expected validation error, got nil")
+	}
+
+	info, ok := m.Info("registeronerror")
+	if !ok {
+		t.Fatal("expected config to stay registered")
+	}
+	if info.Active || info.Rejected == nil {
+		t.Errorf("expected inactive config with rejected revision, got %+v", info)
+	}
+
+	writeYamlFile(t, path, "name: fixed\ncount: 5")
+	m.reloadConfig(context.Background(), "registeronerror")
+
+	select {
+	case isValid := <-updates:
+		if !isValid {
+			t.Error("expected valid update after fixing the file")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("callback was not called after fixing the file")
+	}
+
+	if cfg := m.Get("registeronerror").(TestConfig); cfg.Name != "fixed" {
+		t.Errorf("expected fixed config, got %+v", cfg)
+	}
+}
+
 func TestRegister_FileNotFound(t *testing.T) {
 	m, _, _ := setupTestManager(t)
 	defer m.Close()
@@ -173,3 +223,133 @@ func TestClose_NoError(t *testing.T) {
 		t.Errorf("Close() error: %v", err)
 	}
 }
+
+func TestReload_KeepsLastGoodByDefault(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "keepgood.yaml")
+	writeYamlFile(t, path, "name: good\ncount: 5")
+
+	updates := make(chan bool, 2)
+	err := m.Register(
+		"keepgood",
+		TestConfig{},
+		func(
+			cfg any,
+			isValid bool,
+		) {
+			updates <- isValid
+		},
+	)
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	<-updates
+
+	writeYamlFile(t, path, "name: good\ncount: 500")
+	m.reloadConfig(context.Background(), "keepgood")
+
+	select {
+	case <-updates:
+		t.Error("callback must not be called when keeping last good config")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cfg := m.Get("keepgood").(TestConfig)
+	if cfg.Count != 5 {
+		t.Errorf("expected last good count 5, got %d", cfg.Count)
+	}
+
+	info, _ := m.Info("keepgood")
+	if info.Rejected == nil {
+		t.Fatal("expected rejected revision to be recorded")
+	}
+	if info.Policy != PolicyKeepLastGood {
+		t.Errorf("expected policy %s, got %s", PolicyKeepLastGood, info.Policy)
+	}
+}
+
+func TestReload_DisablePolicy(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "disablepolicy.yaml")
+	writeYamlFile(t, path, "name: good\ncount: 5")
+
+	updates := make(chan bool, 2)
+	err := m.Register(
+		"disablepolicy",
+		TestConfig{},
+		func(
+			cfg any,
+			isValid bool,
+		) {
+			updates <- isValid
+		},
+		WithReloadPolicy(PolicyDisable),
+	)
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	<-updates
+
+	writeYamlFile(t, path, "count: 5")
+	m.reloadConfig(context.Background(), "disablepolicy")
+
+	select {
+	case isValid := <-updates:
+		if isValid {
+			t.Error("expected invalid update for disable policy")
+		}
+	case <-time.After(time.Second):
+		t.Error("callback was not called for disable policy")
+	}
+}
+
+func TestReload_DeliversChangesToChangeCallback(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "changes.yaml")
+	writeYamlFile(t, path, "name: first\ncount: 1")
+
+	events := make(chan UpdateEvent, 2)
+	err := m.Register(
+		"changes",
+		TestConfig{},
+		nil,
+		WithChangeCallback(
+			func(
+				ctx context.Context,
+				event UpdateEvent,
+			) {
+				events <- event
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	initial := <-events
+	if !initial.Valid || len(initial.Changes) != 0 {
+		t.Errorf("unexpected initial event: %+v", initial)
+	}
+
+	writeYamlFile(t, path, "name: first\ncount: 7")
+	m.reloadConfig(context.Background(), "changes")
+
+	select {
+	case event := <-events:
+		if len(event.Changes) != 1 {
+			t.Fatalf("expected 1 change, got %d: %+v", len(event.Changes), event.Changes)
+		}
+		c := event.Changes[0]
+		if c.Path != "count" || c.Old != 1 || c.New != 7 {
+			t.Errorf("unexpected change: %+v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("change callback was not called")
+	}
+}
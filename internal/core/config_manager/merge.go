@@ -0,0 +1,348 @@
+package config_manager
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var listDirectives = []string{
+	DirectiveRemove,
+	DirectiveKey,
+	DirectiveItems,
+	DirectivePrepend,
+	DirectiveAppend,
+}
+
+type directiveError struct {
+	Path    string
+	Rule    string
+	Message string
+}
+
+func (e *directiveError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+func (e *directiveError) rule() string {
+	if e.Rule == "" {
+		return "merge_directive"
+	}
+	return e.Rule
+}
+
+func mergeInto(
+	base, merge map[string]any,
+	prefix string,
+) error {
+	for k, v := range merge {
+		path := joinYamlPath(prefix, k)
+		if strings.HasPrefix(k, DirectivePrefix) {
+			return &directiveError{Path: path, Message: fmt.Sprintf("directive %q is not allowed here", k)}
+		}
+
+		mergeMap, isMergeMap := v.(map[string]any)
+		if isMergeMap && hasDirective(mergeMap) {
+			result, keep, err := applyDirectives(base[k], mergeMap, path)
+			if err != nil {
+				return err
+			}
+			if !keep {
+				delete(base, k)
+				continue
+			}
+			base[k] = result
+			continue
+		}
+
+		if isMergeMap {
+			baseMap, isBaseMap := base[k].(map[string]any)
+			if !isBaseMap {
+				baseMap = make(map[string]any)
+			}
+			if err := mergeInto(baseMap, mergeMap, path); err != nil {
+				return err
+			}
+			base[k] = baseMap
+			continue
+		}
+
+		base[k] = v
+	}
+	return nil
+}
+
+func applyDirectives(
+	current any,
+	directives map[string]any,
+	path string,
+) (any, bool, error) {
+	for k := range directives {
+		if !isKnownDirective(k) {
+			return nil, false, &directiveError{Path: path, Message: fmt.Sprintf("unknown merge directive %q", k)}
+		}
+	}
+
+	if v, ok := directives[DirectiveDelete]; ok {
+		if len(directives) > 1 {
+			return nil, false, &directiveError{Path: path, Message: DirectiveDelete + " cannot be combined with other directives"}
+		}
+		if v != true {
+			return nil, false, &directiveError{Path: path, Message: DirectiveDelete + " must be true"}
+		}
+		return nil, false, nil
+	}
+
+	if v, ok := directives[DirectiveReplace]; ok {
+		if len(directives) > 1 {
+			return nil, false, &directiveError{Path: path, Message: DirectiveReplace + " cannot be combined with other directives"}
+		}
+		return v, true, nil
+	}
+
+	list, err := listValue(current, path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key, _ := directives[DirectiveKey].(string)
+	if _, ok := directives[DirectiveKey]; ok && key == "" {
+		return nil, false, &directiveError{Path: path, Message: DirectiveKey + " must be a non-empty string"}
+	}
+
+	if v, ok := directives[DirectiveRemove]; ok {
+		values, err := directiveList(v, path, DirectiveRemove)
+		if err != nil {
+			return nil, false, err
+		}
+		list = removeItems(list, values, key)
+	}
+
+	if v, ok := directives[DirectiveItems]; ok {
+		if key == "" {
+			return nil, false, &directiveError{Path: path, Message: DirectiveItems + " requires " + DirectiveKey}
+		}
+		items, err := directiveList(v, path, DirectiveItems)
+		if err != nil {
+			return nil, false, err
+		}
+		if list, err = mergeByKey(list, items, key, path); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if v, ok := directives[DirectivePrepend]; ok {
+		values, err := directiveList(v, path, DirectivePrepend)
+		if err != nil {
+			return nil, false, err
+		}
+		list = append(append([]any{}, values...), list...)
+	}
+
+	if v, ok := directives[DirectiveAppend]; ok {
+		values, err := directiveList(v, path, DirectiveAppend)
+		if err != nil {
+			return nil, false, err
+		}
+		list = append(list, values...)
+	}
+
+	return list, true, nil
+}
+
+func mergeByKey(
+	list, items []any,
+	key, path string,
+) ([]any, error) {
+	for i, raw := range items {
+		itemPath := fmt.Sprintf("%s.%s[%d]", path, DirectiveItems, i)
+
+		item, ok := raw.(map[string]any)
+		if !ok {
+			return nil, &directiveError{Path: itemPath, Message: "item must be an object"}
+		}
+		id, ok := item[key]
+		if !ok {
+			return nil, &directiveError{Path: itemPath, Message: fmt.Sprintf("item is missing key field %q", key)}
+		}
+
+		item = cloneMap(item)
+		deleteItem := item[DirectiveDelete] == true
+		delete(item, DirectiveDelete)
+
+		index := indexByKey(list, key, id)
+		switch {
+		case deleteItem:
+			if index >= 0 {
+				list = append(list[:index:index], list[index+1:]...)
+			}
+		case index >= 0:
+			target := cloneMap(list[index].(map[string]any))
+			if err := mergeInto(target, item, fmt.Sprintf("%s[%d]", path, index)); err != nil {
+				return nil, err
+			}
+			list[index] = target
+		default:
+			target := make(map[string]any)
+			if err := mergeInto(target, item, itemPath); err != nil {
+				return nil, err
+			}
+			list = append(list, target)
+		}
+	}
+	return list, nil
+}
+
+func removeItems(
+	list, values []any,
+	key string,
+) []any {
+	result := make([]any, 0, len(list))
+	for _, item := range list {
+		removed := false
+		for _, v := range values {
+			if valuesEqual(item, v) {
+				removed = true
+				break
+			}
+			if obj, ok := item.(map[string]any); ok && key != "" && valuesEqual(obj[key], v) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func indexByKey(
+	list []any,
+	key string,
+	id any,
+) int {
+	for i, item := range list {
+		if obj, ok := item.(map[string]any); ok && valuesEqual(obj[key], id) {
+			return i
+		}
+	}
+	return -1
+}
+
+func listValue(
+	current any,
+	path string,
+) ([]any, error) {
+	switch v := current.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		return append([]any{}, v...), nil
+	}
+	return nil, &directiveError{Path: path, Message: "list directives require a list in the base configuration"}
+}
+
+func directiveList(
+	value any,
+	path, directive string,
+) ([]any, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, &directiveError{Path: path, Message: directive + " expects a list"}
+	}
+	return list, nil
+}
+
+func hasDirective(data map[string]any) bool {
+	for k := range data {
+		if strings.HasPrefix(k, DirectivePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDirectives(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		if hasDirective(v) {
+			return true
+		}
+		for _, child := range v {
+			if containsDirectives(child) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if containsDirectives(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isKnownDirective(key string) bool {
+	if key == DirectiveDelete || key == DirectiveReplace {
+		return true
+	}
+	for _, d := range listDirectives {
+		if d == key {
+			return true
+		}
+	}
+	return false
+}
+
+func valuesEqual(a, b any) bool {
+	ai, aInt := asInt64(a)
+	bi, bInt := asInt64(b)
+	if aInt && bInt {
+		return ai == bi
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func asInt64(v any) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+func joinYamlPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func directiveValidationError(
+	name, file string,
+	err error,
+) error {
+	var dErr *directiveError
+	if !errors.As(err, &dErr) {
+		return err
+	}
+
+	return &ValidationError{
+		Config: name,
+		Fields: []FieldError{
+			{
+				Path:    dErr.Path,
+				File:    file,
+				Rule:    dErr.rule(),
+				Message: dErr.Message,
+			},
+		},
+		err: fmt.Errorf("%w: %v", ErrConfigParse, err),
+	}
+}
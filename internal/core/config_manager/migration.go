@@ -0,0 +1,318 @@
+package config_manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+type MigrationFunc func(data map[string]any) (map[string]any, error)
+
+type migrationPlan struct {
+	Version    int
+	Migrations map[int]MigrationFunc
+}
+
+type migratedDoc struct {
+	Path string
+	Data map[string]any
+	From int
+}
+
+func (m *Manager) setMigrationPlan(
+	name string,
+	plan *migrationPlan,
+) {
+	m.migrationMu.Lock()
+	defer m.migrationMu.Unlock()
+
+	if plan == nil {
+		delete(m.migrations, name)
+		return
+	}
+	m.migrations[name] = plan
+}
+
+func (m *Manager) migrationPlanFor(name string) *migrationPlan {
+	m.migrationMu.RLock()
+	defer m.migrationMu.RUnlock()
+	return m.migrations[name]
+}
+
+func (m *Manager) SchemaVersion(name string) int {
+	if plan := m.migrationPlanFor(name); plan != nil {
+		return plan.Version
+	}
+	return InitialSchemaVersion
+}
+
+func (m *Manager) migrate(
+	name, path string,
+	data map[string]any,
+) (map[string]any, int, error) {
+	version := InitialSchemaVersion
+	if raw, ok := data[KeySchemaVersion]; ok {
+		v, ok := asInt64(raw)
+		if !ok || v < InitialSchemaVersion {
+			return nil, 0, schemaVersionError(name, path, "invalid_version", fmt.Sprintf("must be an integer >= %d", InitialSchemaVersion))
+		}
+		version = int(v)
+	}
+	delete(data, KeySchemaVersion)
+
+	plan := m.migrationPlanFor(name)
+	if plan == nil {
+		return data, version, nil
+	}
+
+	if version > plan.Version {
+		return nil, 0, schemaVersionError(
+			name, path, "unsupported_version",
+			fmt.Sprintf("version %d is newer than supported version %d", version, plan.Version),
+		)
+	}
+
+	for v := version; v < plan.Version; v++ {
+		fn, ok := plan.Migrations[v]
+		if !ok {
+			return nil, 0, schemaVersionError(name, path, "migration", fmt.Sprintf("no migration from version %d", v))
+		}
+
+		migrated, err := fn(data)
+		if err != nil {
+			return nil, 0, schemaVersionError(name, path, "migration", fmt.Sprintf("migration from version %d failed: %v", v, err))
+		}
+		if migrated == nil {
+			migrated = make(map[string]any)
+		}
+		data = migrated
+
+		m.log.Debug(
+			"configuration migrated",
+			zap.String("config", name),
+			zap.String("path", path),
+			zap.Int("from", v),
+			zap.Int("to", v+1),
+		)
+	}
+
+	return data, version, nil
+}
+
+func (m *Manager) migrateDoc(
+	name, path string,
+	data map[string]any,
+	res *loadResult,
+) (map[string]any, error) {
+	migrated, from, err := m.migrate(name, path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan := m.migrationPlanFor(name); plan != nil && from < plan.Version {
+		res.migrated = append(res.migrated, migratedDoc{Path: path, Data: cloneMap(migrated), From: from})
+		m.log.Info(
+			"configuration file uses an outdated schema version",
+			zap.String("config", name),
+			zap.String("path", path),
+			zap.Int("version", from),
+			zap.Int("current", plan.Version),
+		)
+	}
+
+	return migrated, nil
+}
+
+func (m *Manager) persistMigrations(
+	ctx context.Context,
+	name string,
+	res *loadResult,
+) {
+	if !m.migrationWriteBack || m.readOnly || len(res.migrated) == 0 {
+		return
+	}
+
+	version := m.SchemaVersion(name)
+	for _, doc := range res.migrated {
+		original, err := os.ReadFile(doc.Path)
+		if err != nil {
+			m.log.WithCtx(ctx).Error("failed to read migrated file", zap.String("path", doc.Path), zap.Error(err))
+			continue
+		}
+
+		// The migrated data has its includes resolved; writing it back would inline the shared fragments.
+		if raw, err := codecFor(doc.Path).Decode(original); err != nil || containsInclude(raw) {
+			m.log.WithCtx(ctx).Warn(
+				"skipping migration write-back for file with includes, update it manually",
+				zap.String("config", name),
+				zap.String("path", doc.Path),
+				zap.Int("from", doc.From),
+				zap.Int("to", version),
+			)
+			continue
+		}
+		if _, isToml := codecFor(doc.Path).(tomlCodec); isToml && hasLineComments(original) {
+			m.log.WithCtx(ctx).Warn(
+				"skipping migration write-back for TOML file with comments, update it manually",
+				zap.String("config", name),
+				zap.String("path", doc.Path),
+				zap.Int("from", doc.From),
+				zap.Int("to", version),
+			)
+			continue
+		}
+
+		backup := fmt.Sprintf("%s.v%d%s", doc.Path, doc.From, ExtensionBackup)
+		if err := writeFileAtomic(backup, original); err != nil {
+			m.log.WithCtx(ctx).Error("failed to back up migrated file", zap.String("path", backup), zap.Error(err))
+			continue
+		}
+
+		data := cloneMap(doc.Data)
+		data[KeySchemaVersion] = version
+
+		content, err := encodeMigrated(doc.Path, original, data)
+		if err != nil {
+			m.log.WithCtx(ctx).Error("failed to marshal migrated file", zap.String("path", doc.Path), zap.Error(err))
+			continue
+		}
+		modeline := []byte(schemaModeline(name))
+		if _, isYaml := codecFor(doc.Path).(yamlCodec); isYaml && doc.Path == m.basePath(name) && !bytes.HasPrefix(content, modeline) {
+			content = append(modeline, content...)
+		}
+
+		if err := writeFileAtomic(doc.Path, content); err != nil {
+			m.log.WithCtx(ctx).Error("failed to write migrated file", zap.String("path", doc.Path), zap.Error(err))
+			continue
+		}
+
+		m.log.WithCtx(ctx).Info(
+			"migrated configuration written back",
+			zap.String("config", name),
+			zap.String("path", doc.Path),
+			zap.String("backup", backup),
+			zap.Int("from", doc.From),
+			zap.Int("to", version),
+		)
+	}
+}
+
+// encodeMigrated edits the original YAML tree instead of re-encoding it, so comments and key order survive.
+func encodeMigrated(
+	path string,
+	original []byte,
+	data map[string]any,
+) ([]byte, error) {
+	codec := codecFor(path)
+	if _, isYaml := codec.(yamlCodec); !isYaml {
+		return codec.Encode(data)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(original, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return codec.Encode(data)
+	}
+
+	root := doc.Content[0]
+	if len(root.Content) > 0 {
+		if _, kept := data[root.Content[0].Value]; !kept && root.Content[0].HeadComment != "" {
+			doc.HeadComment = strings.TrimSpace(doc.HeadComment + "\n" + root.Content[0].HeadComment)
+		}
+	}
+	if err := patchYamlNode(root, data); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(&doc)
+}
+
+func patchYamlNode(
+	node *yaml.Node,
+	value any,
+) error {
+	var current any
+	if err := node.Decode(&current); err == nil && valuesEqual(current, value) {
+		return nil
+	}
+
+	data, isMap := value.(map[string]any)
+	if !isMap || node.Kind != yaml.MappingNode {
+		var fresh yaml.Node
+		if err := fresh.Encode(value); err != nil {
+			return err
+		}
+		fresh.HeadComment, fresh.LineComment, fresh.FootComment = node.HeadComment, node.LineComment, node.FootComment
+		*node = fresh
+		return nil
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	seen := make(map[string]bool, len(data))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		v, ok := data[key]
+		if !ok {
+			continue
+		}
+		if err := patchYamlNode(node.Content[i+1], v); err != nil {
+			return err
+		}
+		content = append(content, node.Content[i], node.Content[i+1])
+		seen[key] = true
+	}
+
+	added := make([]string, 0, len(data))
+	for key := range data {
+		if !seen[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		var keyNode, valueNode yaml.Node
+		if err := keyNode.Encode(key); err != nil {
+			return err
+		}
+		if err := valueNode.Encode(data[key]); err != nil {
+			return err
+		}
+		content = append(content, &keyNode, &valueNode)
+	}
+
+	node.Content = content
+	return nil
+}
+
+func hasLineComments(content []byte) bool {
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaVersionError(
+	name, path, rule, message string,
+) *ValidationError {
+	return &ValidationError{
+		Config: name,
+		Fields: []FieldError{
+			{
+				Path:    KeySchemaVersion,
+				File:    path,
+				Rule:    rule,
+				Message: message,
+			},
+		},
+		err: fmt.Errorf("%w: %s", ErrSchemaVersion, message),
+	}
+}
@@ -0,0 +1,205 @@
+package config_manager
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func renameCountMigrations() map[int]MigrationFunc {
+	return map[int]MigrationFunc{
+		1: func(data map[string]any) (map[string]any, error) {
+			if v, ok := data["amount"]; ok {
+				data["count"] = v
+				delete(data, "amount")
+			}
+			return data, nil
+		},
+	}
+}
+
+func TestRegister_AppliesMigrations(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "migrated.yaml"), "name: old\namount: 5")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.migrated.yaml"), "amount: 7")
+
+	err := m.Register("migrated", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	cfg := m.Get("migrated").(TestConfig)
+	if cfg.Count != 7 {
+		t.Errorf("expected migrated count 7, got %d", cfg.Count)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(defaultPath, "migrated.yaml"))
+	if !strings.Contains(string(content), "amount") {
+		t.Error("file must not be rewritten without write-back mode")
+	}
+}
+
+func TestRegister_CurrentVersionSkipsMigrations(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "current.yaml"), "schema_version: 2\nname: new\ncount: 3")
+
+	err := m.Register("current", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	if cfg := m.Get("current").(TestConfig); cfg.Count != 3 {
+		t.Errorf("expected count 3, got %d", cfg.Count)
+	}
+}
+
+func TestRegister_NewerSchemaVersionRejected(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "future.yaml"), "schema_version: 3\nname: new")
+
+	err := m.Register("future", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if !errors.Is(err, ErrSchemaVersion) {
+		t.Fatalf("expected ErrSchemaVersion, got %v", err)
+	}
+
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Path != KeySchemaVersion || fields[0].Rule != "unsupported_version" {
+		t.Errorf("unexpected field errors: %+v", fields)
+	}
+}
+
+func TestRegister_MigrationWriteBack(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.migrationWriteBack = true
+
+	path := filepath.Join(defaultPath, "writeback.yaml")
+	writeYamlFile(t, path, "name: old\namount: 5")
+
+	err := m.Register("writeback", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	backup, err := os.ReadFile(path + ".v1" + ExtensionBackup)
+	if err != nil {
+		t.Fatalf("expected backup file: %v", err)
+	}
+	if !strings.Contains(string(backup), "amount: 5") {
+		t.Errorf("backup must hold the original content, got %q", backup)
+	}
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "schema_version: 2") || !strings.Contains(string(content), "count: 5") {
+		t.Errorf("expected migrated file content, got %q", content)
+	}
+
+	res, err := m.load("writeback", m.registry["writeback"].StructType)
+	if err != nil {
+		t.Fatalf("load() after write-back error: %v", err)
+	}
+	if len(res.migrated) != 0 {
+		t.Error("written back file must not require migration again")
+	}
+}
+
+func TestRegister_MigrationWriteBackKeepsComments(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.migrationWriteBack = true
+
+	path := filepath.Join(defaultPath, "writebackdoc.yaml")
+	writeYamlFile(t, path, "# file header\nschema_version: 1\n# display name\nname: old # keep me\namount: 5\n")
+
+	err := m.Register("writebackdoc", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	for _, want := range []string{"# file header\nschema_version: 2\n", "# display name\nname: old # keep me\n", "count: 5\n"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected %q in migrated file, got %q", want, content)
+		}
+	}
+	if strings.Contains(string(content), "amount") {
+		t.Errorf("renamed key must be dropped, got %q", content)
+	}
+}
+
+func TestRegister_MigrationWriteBackSkipsCommentedToml(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.migrationWriteBack = true
+
+	path := filepath.Join(defaultPath, "writebacktoml.toml")
+	original := "# display name\nname = \"old\"\namount = 5\n"
+	writeYamlFile(t, path, original)
+
+	err := m.Register("writebacktoml", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if string(content) != original {
+		t.Errorf("TOML file with comments must not be rewritten, got %q", content)
+	}
+}
+
+func TestRegister_MigrationWriteBackSkipsFilesWithIncludes(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.migrationWriteBack = true
+
+	writeYamlFile(t, filepath.Join(defaultPath, "shared", "name.yaml"), "name: shared\n")
+	path := filepath.Join(defaultPath, "writebackinc.yaml")
+	original := "$include: shared/name.yaml\namount: 5\n"
+	writeYamlFile(t, path, original)
+
+	err := m.Register("writebackinc", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if cfg := m.Get("writebackinc").(TestConfig); cfg.Name != "shared" || cfg.Count != 5 {
+		t.Errorf("unexpected migrated config: %+v", cfg)
+	}
+
+	content, _ := os.ReadFile(path)
+	if string(content) != original {
+		t.Errorf("file with includes must not be rewritten, got %q", content)
+	}
+	if _, err := os.Stat(path + ".v1" + ExtensionBackup); !os.IsNotExist(err) {
+		t.Error("no backup expected when write-back is skipped")
+	}
+}
+
+func TestPatchMerge_StampsSchemaVersion(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "stamped.yaml"), "schema_version: 2\nname: new\ncount: 1")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.stamped.yaml"), "amount: 4")
+
+	err := m.Register("stamped", TestConfig{}, nil, WithMigrations(2, renameCountMigrations()))
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	doc, err := m.PatchMerge(t.Context(), "stamped", map[string]any{"enabled": true})
+	if err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+
+	if doc.Merge[KeySchemaVersion] != 2 || doc.Merge["count"] != 4 {
+		t.Errorf("expected migrated and stamped merge doc, got %+v", doc.Merge)
+	}
+}
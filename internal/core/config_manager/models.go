@@ -1,7 +1,16 @@
 package config_manager
 
 import (
+	"context"
 	"reflect"
+	"time"
+)
+
+type ReloadPolicy string
+
+const (
+	PolicyDisable      ReloadPolicy = "disable"
+	PolicyKeepLastGood ReloadPolicy = "keep_last_good"
 )
 
 type UpdateCallback func(
@@ -9,12 +18,62 @@ type UpdateCallback func(
 	isValid bool,
 )
 
+type UpdateEvent struct {
+	Config       any
+	Valid        bool
+	KeptLastGood bool
+	Changes      []Change
+	Errors       []FieldError
+}
+
+type ChangeCallback func(
+	ctx context.Context,
+	event UpdateEvent,
+)
+
 type ConfigMeta struct {
 	Name         string
 	StructType   reflect.Type
 	OnUpdate     UpdateCallback
+	OnChange     ChangeCallback
 	CurrentValue any
 	IsUsed       bool
+	EnvOverrides []EnvOverride
+	Policy       ReloadPolicy
+	Rejected     *RejectedRevision
+	template     any
+	dependsOn    []string
+	guilds       map[string]any
+	guildGen     uint64
+	sourceHash   string
+	migration    *migrationPlan
+	secrets      *secretSet
+
+	registerOnError bool
+}
+
+type RejectedRevision struct {
+	TraceID    string       `json:"trace_id"`
+	Error      string       `json:"error"`
+	Errors     []FieldError `json:"errors,omitempty"`
+	RejectedAt time.Time    `json:"rejected_at"`
+}
+
+type ConfigInfo struct {
+	Name         string            `json:"name"`
+	Policy       ReloadPolicy      `json:"policy"`
+	Active       bool              `json:"active"`
+	Rejected     *RejectedRevision `json:"rejected,omitempty"`
+	EnvOverrides []EnvOverride     `json:"env_overrides,omitempty"`
+	Secrets      []SecretRef       `json:"secrets,omitempty"`
+}
+
+type GuildCheck struct {
+	GuildID string       `json:"guild_id"`
+	Path    string       `json:"path"`
+	Valid   bool         `json:"valid"`
+	Error   string       `json:"error,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
 }
 
 type FileStatus struct {
@@ -22,6 +81,38 @@ type FileStatus struct {
 	IsUsed bool
 }
 
+type EnvOverride struct {
+	Config   string `json:"config"`
+	Key      string `json:"key"`
+	Variable string `json:"variable"`
+}
+
 type ScanReport struct {
-	Files []FileStatus
+	Files        []FileStatus
+	EnvOverrides []EnvOverride
+	Secrets      []SecretRef
+}
+
+func (meta *ConfigMeta) info() ConfigInfo {
+	return ConfigInfo{
+		Name:         meta.Name,
+		Policy:       meta.Policy,
+		Active:       meta.CurrentValue != nil,
+		Rejected:     meta.Rejected,
+		EnvOverrides: meta.EnvOverrides,
+		Secrets:      meta.secrets.refs(),
+	}
+}
+
+func (meta *ConfigMeta) notify(
+	ctx context.Context,
+	event UpdateEvent,
+) {
+	if meta.OnChange != nil {
+		meta.OnChange(ctx, event)
+		return
+	}
+	if meta.OnUpdate != nil && !event.KeptLastGood {
+		meta.OnUpdate(event.Config, event.Valid)
+	}
 }
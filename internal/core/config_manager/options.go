@@ -0,0 +1,88 @@
+package config_manager
+
+import "time"
+
+type Option func(meta *ConfigMeta)
+
+func WithReloadPolicy(policy ReloadPolicy) Option {
+	return func(meta *ConfigMeta) {
+		if policy != "" {
+			meta.Policy = policy
+		}
+	}
+}
+
+func WithChangeCallback(callback ChangeCallback) Option {
+	return func(meta *ConfigMeta) {
+		meta.OnChange = callback
+	}
+}
+
+func WithMigrations(
+	version int,
+	migrations map[int]MigrationFunc,
+) Option {
+	return func(meta *ConfigMeta) {
+		if version > InitialSchemaVersion || len(migrations) > 0 {
+			meta.migration = &migrationPlan{Version: version, Migrations: migrations}
+		}
+	}
+}
+
+func WithDependsOn(names ...string) Option {
+	return func(meta *ConfigMeta) {
+		meta.dependsOn = append(meta.dependsOn, names...)
+	}
+}
+
+// WithRegisterOnError keeps a config that fails its first load registered, so a fixed file is hot-reloaded.
+func WithRegisterOnError() Option {
+	return func(meta *ConfigMeta) {
+		meta.registerOnError = true
+	}
+}
+
+type ManagerOption func(m *Manager)
+
+func WithPollInterval(interval time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.pollInterval = interval
+	}
+}
+
+func WithBatchReload(window time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.batchWindow = window
+	}
+}
+
+func WithReadOnly() ManagerOption {
+	return func(m *Manager) {
+		m.readOnly = true
+	}
+}
+
+func WithSecrets(resolver SecretResolver) ManagerOption {
+	return func(m *Manager) {
+		m.secrets = resolver
+	}
+}
+
+func WithSource(
+	source ConfigSource,
+	interval time.Duration,
+) ManagerOption {
+	return func(m *Manager) {
+		m.source = source
+		m.sourceInterval = interval
+		if m.sourceInterval <= 0 {
+			m.sourceInterval = DefaultSourceInterval
+		}
+	}
+}
+
+func WithMigrationWriteBack(enabled bool) ManagerOption {
+	return func(m *Manager) {
+		m.migrationWriteBack = enabled
+	}
+}
@@ -0,0 +1,250 @@
+package config_manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+type ConfigDocument struct {
+	Name      string         `json:"name"`
+	Effective map[string]any `json:"effective"`
+	Merge     map[string]any `json:"merge"`
+}
+
+func (m *Manager) Document(name string) (ConfigDocument, error) {
+	m.mu.RLock()
+	meta, ok := m.registry[name]
+	var current any
+	var secrets *secretSet
+	if ok {
+		current = meta.CurrentValue
+		secrets = meta.secrets
+	}
+	m.mu.RUnlock()
+
+	if !ok {
+		return ConfigDocument{}, ErrConfigNotFound
+	}
+
+	effective, err := toYamlMap(current)
+	if err != nil {
+		return ConfigDocument{}, err
+	}
+
+	merge, err := m.readMergeRaw(name)
+	if err != nil {
+		return ConfigDocument{}, err
+	}
+	if merge == nil {
+		merge = make(map[string]any)
+	}
+
+	return ConfigDocument{
+		Name:      name,
+		Effective: secrets.redactValue(effective).(map[string]any),
+		Merge:     merge,
+	}, nil
+}
+
+func (m *Manager) ReplaceMerge(
+	ctx context.Context,
+	name string,
+	data map[string]any,
+) (ConfigDocument, error) {
+	return m.writeMerge(ctx, name, data, false)
+}
+
+func (m *Manager) PatchMerge(
+	ctx context.Context,
+	name string,
+	data map[string]any,
+) (ConfigDocument, error) {
+	return m.writeMerge(ctx, name, data, true)
+}
+
+func (m *Manager) writeMerge(
+	ctx context.Context,
+	name string,
+	data map[string]any,
+	patch bool,
+) (ConfigDocument, error) {
+	m.mu.RLock()
+	meta, ok := m.registry[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return ConfigDocument{}, ErrConfigNotFound
+	}
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	path := m.mergePath(name)
+
+	existing, err := m.readMergeRaw(name)
+	if err != nil {
+		m.recordRejection(ctx, name, SourceAPI, "load", err)
+		return ConfigDocument{}, err
+	}
+
+	candidate := make(map[string]any)
+	if patch {
+		if existing != nil {
+			migrated, _, err := m.migrate(name, path, existing)
+			if err != nil {
+				return ConfigDocument{}, err
+			}
+			candidate = migrated
+		}
+		patchMap(candidate, cloneMap(data))
+	} else if data != nil {
+		candidate = cloneMap(data)
+	}
+
+	if plan := m.migrationPlanFor(name); plan != nil {
+		if _, ok := candidate[KeySchemaVersion]; !ok {
+			candidate[KeySchemaVersion] = plan.Version
+		}
+	}
+
+	content, err := codecFor(path).Encode(candidate)
+	if err != nil {
+		return ConfigDocument{}, fmt.Errorf("marshal merge file: %w", err)
+	}
+
+	baseData, err := m.readBase(name)
+	if err != nil {
+		return ConfigDocument{}, err
+	}
+
+	resolved, _, err := m.resolveFileIncludes(path, cloneMap(candidate))
+	if err != nil {
+		err = configFileError(name, path, "resolve merge config", err)
+		m.recordRejection(ctx, name, SourceAPI, "load", err)
+		return ConfigDocument{}, err
+	}
+
+	res, err := m.compose(
+		name,
+		meta.StructType,
+		baseData,
+		[]mergeLayer{{Path: path, Data: resolved}},
+		sourceDoc{Path: path, Content: content},
+	)
+	if err != nil {
+		m.recordRejection(ctx, name, SourceAPI, "load", err)
+		return ConfigDocument{}, err
+	}
+
+	if err := m.validate(name, res); err != nil {
+		m.recordRejection(ctx, name, SourceAPI, "validation", err)
+		return ConfigDocument{}, err
+	}
+
+	previous, _ := os.ReadFile(path)
+	if err := writeFileAtomic(path, content); err != nil {
+		return ConfigDocument{}, fmt.Errorf("write merge file: %w", err)
+	}
+
+	// An identical write is skipped by the reload, so it must not claim the next one.
+	if !bytes.Equal(previous, content) {
+		m.mu.Lock()
+		m.origins[name] = originOf(ctx)
+		m.mu.Unlock()
+	}
+
+	m.log.WithCtx(ctx).Info(
+		"merge configuration written",
+		zap.String("config", name),
+		zap.String("path", path),
+		zap.Bool("patch", patch),
+	)
+
+	effective, err := toYamlMap(res.Value)
+	if err != nil {
+		return ConfigDocument{}, err
+	}
+
+	return ConfigDocument{
+		Name:      name,
+		Effective: res.secrets.redactValue(effective).(map[string]any),
+		Merge:     candidate,
+	}, nil
+}
+
+func writeFileAtomic(
+	path string,
+	content []byte,
+) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+func patchMap(base, patch map[string]any) {
+	for k, v := range patch {
+		if baseMap, ok := base[k].(map[string]any); ok {
+			if patchValue, ok := v.(map[string]any); ok {
+				patchMap(baseMap, patchValue)
+				continue
+			}
+		}
+		base[k] = v
+	}
+}
+
+func cloneMap(src map[string]any) map[string]any {
+	if src == nil {
+		return nil
+	}
+	dst := make(map[string]any, len(src))
+	for k, v := range src {
+		dst[k] = cloneValue(v)
+	}
+	return dst
+}
+
+func cloneValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return cloneMap(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = cloneValue(item)
+		}
+		return out
+	default:
+		return val
+	}
+}
@@ -0,0 +1,134 @@
+package config_manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPatchMerge_WritesValidatedMergeFile(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "persist.yaml"), "name: base\ncount: 1")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.persist.yaml"), "enabled: true")
+	_ = m.Register("persist", TestConfig{}, nil)
+
+	doc, err := m.PatchMerge(context.Background(), "persist", map[string]any{"count": float64(5)})
+	if err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+	if doc.Effective["count"] != 5 || doc.Effective["enabled"] != true {
+		t.Errorf("unexpected effective config: %v", doc.Effective)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(mergePath, "MERGE.persist.yaml"))
+	if err != nil {
+		t.Fatalf("merge file not written: %v", err)
+	}
+
+	written := make(map[string]any)
+	if err := yaml.Unmarshal(raw, &written); err != nil {
+		t.Fatalf("merge file is not valid yaml: %v", err)
+	}
+	if written["enabled"] != true || written["count"] != 5 {
+		t.Errorf("patch must keep existing keys and add new ones, got %v", written)
+	}
+
+	entries, _ := os.ReadDir(mergePath)
+	for _, e := range entries {
+		if e.Name() != "MERGE.persist.yaml" && !e.IsDir() {
+			t.Errorf("unexpected leftover file %s", e.Name())
+		}
+	}
+}
+
+func TestReplaceMerge_RejectsInvalidConfig(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "persistbad.yaml"), "name: base\ncount: 1")
+	_ = m.Register("persistbad", TestConfig{}, nil)
+
+	_, err := m.ReplaceMerge(context.Background(), "persistbad", map[string]any{"count": 500})
+
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(vErr.Fields) != 1 || vErr.Fields[0].Rule != "lte" {
+		t.Errorf("unexpected field errors: %+v", vErr.Fields)
+	}
+
+	if _, err := os.Stat(filepath.Join(mergePath, "MERGE.persistbad.yaml")); !os.IsNotExist(err) {
+		t.Error("merge file must not be written for invalid config")
+	}
+}
+
+func TestReplaceMerge_RejectsUnknownFields(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "persistunknown.yaml"), "name: base")
+	_ = m.Register("persistunknown", TestConfig{}, nil)
+
+	_, err := m.ReplaceMerge(context.Background(), "persistunknown", map[string]any{"bogus": 1})
+	if !errors.Is(err, ErrConfigParse) {
+		t.Errorf("expected ErrConfigParse, got %v", err)
+	}
+}
+
+func TestPatchMerge_KeepsIncludeDirectives(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "persistinc.yaml"), "name: base\n")
+	writeYamlFile(t, filepath.Join(mergePath, "shared", "inner.yaml"), "enabled: true\nlimit: 3\n")
+	mergeFile := filepath.Join(mergePath, "MERGE.persistinc.yaml")
+	writeYamlFile(t, mergeFile, "inner:\n  $include: shared/inner.yaml\n")
+	if err := m.Register("persistinc", IncludeTestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	doc, err := m.PatchMerge(context.Background(), "persistinc", map[string]any{"roles": []any{"1"}})
+	if err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+	if inner, _ := doc.Effective["inner"].(map[string]any); inner["limit"] != 3 {
+		t.Errorf("expected included values in effective config, got %v", doc.Effective)
+	}
+
+	raw, _ := os.ReadFile(mergeFile)
+	written := make(map[string]any)
+	_ = yaml.Unmarshal(raw, &written)
+	inner, _ := written["inner"].(map[string]any)
+	if inner[DirectiveInclude] != "shared/inner.yaml" || inner["limit"] != nil {
+		t.Errorf("expected the include to be kept, got:\n%s", raw)
+	}
+}
+
+func TestPatchMerge_RejectsUnparseableMergeFile(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "persistbad.yaml"), "name: base\ncount: 1\n")
+	if err := m.Register("persistbad", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	mergeFile := filepath.Join(mergePath, "MERGE.persistbad.yaml")
+	writeYamlFile(t, mergeFile, "count: [5\n")
+
+	_, err := m.PatchMerge(context.Background(), "persistbad", map[string]any{"count": 2})
+	if !errors.Is(err, ErrConfigParse) {
+		t.Fatalf("expected ErrConfigParse, got %v", err)
+	}
+
+	raw, _ := os.ReadFile(mergeFile)
+	if string(raw) != "count: [5\n" {
+		t.Errorf("operator file must not be overwritten, got %q", raw)
+	}
+}
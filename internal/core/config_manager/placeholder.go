@@ -1,22 +1,25 @@
 package config_manager
 
 import (
+	"bytes"
 	"fmt"
 	"os"
-
-	"gopkg.in/yaml.v3"
 )
 
 func (m *Manager) createPlaceholder(
-	path string,
+	name, path string,
 	template any,
 ) error {
-	data, err := yaml.Marshal(template)
+	data, err := documentedYaml(template)
 	if err != nil {
 		return fmt.Errorf("marshal template: %w", err)
 	}
 
-	content := []byte(PlaceholderHeader)
+	content := []byte(schemaModeline(name))
+	content = append(content, PlaceholderHeader...)
+	if plan := m.migrationPlanFor(name); plan != nil {
+		content = append(content, fmt.Sprintf("%s: %d\n", KeySchemaVersion, plan.Version)...)
+	}
 	content = append(content, data...)
 
 	if err := os.WriteFile(path, content, 0600); err != nil {
@@ -25,3 +28,7 @@ func (m *Manager) createPlaceholder(
 
 	return nil
 }
+
+func isPlaceholder(content []byte) bool {
+	return bytes.Contains(content, []byte(PlaceholderHeader))
+}
@@ -0,0 +1,93 @@
+package config_manager
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"DiscordBotAgent/internal/core/zap_logger"
+)
+
+func setupReadOnlyManager(t *testing.T) (*Manager, string, string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	defaultPath := filepath.Join(tmpDir, "config_df")
+	mergePath := filepath.Join(tmpDir, "config_mrg")
+
+	m, err := New(zap_logger.NewNop(), defaultPath, mergePath, WithReadOnly())
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	return m, defaultPath, mergePath
+}
+
+func TestReadOnly_RegisterWritesNothing(t *testing.T) {
+	m, defaultPath, mergePath := setupReadOnlyManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "readonly.yaml"), "name: base\ncount: 1\n")
+	if err := m.Register("readonly", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	if err := m.Register("absent", TestConfig{}, nil); !errors.Is(err, ErrConfigMissing) {
+		t.Errorf("expected ErrConfigMissing, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(defaultPath, "absent.yaml")); !os.IsNotExist(err) {
+		t.Error("read-only manager must not create placeholders")
+	}
+	if _, err := os.Stat(filepath.Join(defaultPath, DirSchemas)); !os.IsNotExist(err) {
+		t.Error("read-only manager must not write schemas")
+	}
+	if _, err := os.Stat(mergePath); !os.IsNotExist(err) {
+		t.Error("read-only manager must not create the merge directory or history")
+	}
+}
+
+func TestScanUsage_ReportsOrphanedMergeFiles(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "used.yaml"), "name: used\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.used.yaml"), "count: 2\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.orphan.yaml"), "count: 2\n")
+	writeYamlFile(t, filepath.Join(mergePath, DirGuilds, "123456789012345678", "MERGE.orphan.json"), "{}")
+	_ = m.Register("used", TestConfig{}, nil)
+
+	unused := make(map[string]bool)
+	for _, f := range m.ScanUsage().Files {
+		if !f.IsUsed {
+			unused[filepath.Base(f.Path)] = true
+		}
+	}
+
+	if len(unused) != 2 || !unused["MERGE.orphan.yaml"] || !unused["MERGE.orphan.json"] {
+		t.Errorf("unexpected unused files: %v", unused)
+	}
+}
+
+func TestCheckGuilds_ReportsInvalidOverlay(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "guilded.yaml"), "name: base\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(mergePath, DirGuilds, "123456789012345678", "MERGE.guilded.yaml"), "count: 5\n")
+	writeYamlFile(t, filepath.Join(mergePath, DirGuilds, "223456789012345678", "MERGE.guilded.yaml"), "count: 500\n")
+	if err := m.Register("guilded", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	checks := m.CheckGuilds("guilded")
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 guild checks, got %+v", checks)
+	}
+	if !checks[0].Valid {
+		t.Errorf("guild %s must be valid: %+v", checks[0].GuildID, checks[0])
+	}
+	if checks[1].Valid || len(checks[1].Errors) != 1 || checks[1].Errors[0].Rule != "lte" {
+		t.Errorf("guild %s must be invalid: %+v", checks[1].GuildID, checks[1])
+	}
+}
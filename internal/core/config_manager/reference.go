@@ -0,0 +1,136 @@
+package config_manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func documentedYaml(template any) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(template); err != nil {
+		return nil, err
+	}
+	annotateNode(&root, reflect.TypeOf(template))
+	return yaml.Marshal(&root)
+}
+
+func annotateNode(
+	node *yaml.Node,
+	t reflect.Type,
+) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			annotateNode(child, t)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch t.Kind() {
+			case reflect.Struct:
+				field, _, ok := findFieldByYamlKey(t, key.Value)
+				if !ok {
+					continue
+				}
+				key.HeadComment = fieldComment(field)
+				annotateNode(value, field.Type)
+			case reflect.Map:
+				annotateNode(value, t.Elem())
+			}
+		}
+	case yaml.SequenceNode:
+		// Only the first item is annotated so lists don't repeat the same comments.
+		if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && len(node.Content) > 0 {
+			annotateNode(node.Content[0], t.Elem())
+		}
+	}
+}
+
+func fieldComment(field reflect.StructField) string {
+	var lines []string
+	if desc := field.Tag.Get(TagDescription); desc != "" {
+		lines = append(lines, desc)
+	}
+	if example := field.Tag.Get(TagExample); example != "" {
+		lines = append(lines, "Example: "+example)
+	}
+	if rules := field.Tag.Get("validate"); rules != "" && rules != "-" {
+		lines = append(lines, "Constraints: "+strings.ReplaceAll(rules, ",", ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func exampleValue(example string) any {
+	var value any
+	if err := yaml.Unmarshal([]byte(example), &value); err != nil || value == nil {
+		return example
+	}
+	return value
+}
+
+func (m *Manager) Reference(name string) ([]byte, error) {
+	m.mu.RLock()
+	meta, ok := m.claimed[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrConfigNotFound
+	}
+
+	data, err := documentedYaml(meta.template)
+	if err != nil {
+		return nil, fmt.Errorf("marshal template: %w", err)
+	}
+
+	content := []byte(fmt.Sprintf(ReferenceHeader, name, name, name))
+	if plan := m.migrationPlanFor(name); plan != nil {
+		content = append(content, fmt.Sprintf("%s: %d\n", KeySchemaVersion, plan.Version)...)
+	}
+	return append(content, data...), nil
+}
+
+func (m *Manager) WriteReferences(dir string) ([]string, error) {
+	if dir == "" {
+		dir = filepath.Join(m.pathDefault, DirReference)
+	}
+
+	m.mu.RLock()
+	names := make([]string, 0, len(m.claimed))
+	for name := range m.claimed {
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+	sort.Strings(names)
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("create reference directory: %w", err)
+	}
+
+	var written []string
+	for _, name := range names {
+		content, err := m.Reference(name)
+		if err != nil {
+			return written, fmt.Errorf("reference %s: %w", name, err)
+		}
+
+		path := filepath.Join(dir, name+ExtensionYaml)
+		if err := writeFileAtomic(path, content); err != nil {
+			return written, fmt.Errorf("write reference %s: %w", name, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
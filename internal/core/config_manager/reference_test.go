@@ -0,0 +1,78 @@
+package config_manager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type DocumentedConfig struct {
+	Name  string `yaml:"name" validate:"required" desc:"Display name of the module." example:"Greeter"`
+	Limit int    `yaml:"limit" validate:"gte=0,lte=10"`
+	Inner struct {
+		Enabled bool `yaml:"enabled" desc:"Enables the inner feature."`
+	} `yaml:"inner"`
+}
+
+func TestDocumentedYaml_EmitsFieldComments(t *testing.T) {
+	content, err := documentedYaml(DocumentedConfig{Name: "x"})
+	if err != nil {
+		t.Fatalf("documentedYaml() error: %v", err)
+	}
+
+	expected := []string{
+		"# Display name of the module.\n# Example: Greeter\n# Constraints: required\nname: x\n",
+		"# Constraints: gte=0, lte=10\nlimit: 0\n",
+		"    # Enables the inner feature.\n    enabled: false\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("missing %q in:\n%s", e, content)
+		}
+	}
+}
+
+func TestGenerateSchema_IncludesDescriptionAndExamples(t *testing.T) {
+	schema := GenerateSchema("documented", reflect.TypeOf(DocumentedConfig{}))
+	name := schema["properties"].(map[string]any)["name"].(map[string]any)
+
+	if name["description"] != "Display name of the module." {
+		t.Errorf("unexpected description: %v", name["description"])
+	}
+	if examples, ok := name["examples"].([]any); !ok || examples[0] != "Greeter" {
+		t.Errorf("unexpected examples: %v", name["examples"])
+	}
+}
+
+func TestWriteReferences_IncludesMissingConfigs(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	_ = m.Register("documented", DocumentedConfig{Name: "default"}, nil)
+
+	written, err := m.WriteReferences("")
+	if err != nil {
+		t.Fatalf("WriteReferences() error: %v", err)
+	}
+
+	path := filepath.Join(defaultPath, DirReference, "documented.yaml")
+	if len(written) != 1 || written[0] != path {
+		t.Fatalf("unexpected written files: %v", written)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reference not written: %v", err)
+	}
+	if !strings.Contains(string(content), "CONFIGURATION REFERENCE: documented") ||
+		!strings.Contains(string(content), "# Display name of the module.") {
+		t.Errorf("unexpected reference content:\n%s", content)
+	}
+
+	placeholder, _ := os.ReadFile(filepath.Join(defaultPath, "documented.yaml"))
+	if !strings.Contains(string(placeholder), "# Constraints: gte=0, lte=10") {
+		t.Errorf("placeholder must be documented:\n%s", placeholder)
+	}
+}
@@ -0,0 +1,247 @@
+package config_manager
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	TagSnowflake   = "snowflake"
+	TagHexColor    = "hexcolor"
+	TagDuration    = "duration"
+	TagRegexp      = "regexp"
+	TagEmoji       = "emoji"
+	TagChannelType = "channel_type"
+	TagCron        = "cron"
+	TagByteSize    = "bytesize"
+	TagPercentage  = "percentage"
+
+	SnowflakePattern  = `^[0-9]{17,20}$`
+	HexColorPattern   = `^(#|0x|0X)?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`
+	DurationPattern   = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$|^0$`
+	ByteSizePattern   = `^[0-9]+(\.[0-9]+)?\s*([KkMmGgTt][Ii]?[Bb]|[Bb])?$`
+	PercentagePattern = `^[0-9]+(\.[0-9]+)?\s*%?$`
+	CronPattern       = `^(@(yearly|annually|monthly|weekly|daily|midnight|hourly)|(\S+\s+){4}\S+)$`
+	MaxColorValue     = 0xFFFFFF
+)
+
+var (
+	snowflakeRe   = regexp.MustCompile(SnowflakePattern)
+	hexColorRe    = regexp.MustCompile(HexColorPattern)
+	customEmojiRe = regexp.MustCompile(`^<?a?:?[A-Za-z0-9_~]{2,32}:[0-9]{17,20}>?$`)
+)
+
+var ChannelTypes = []string{
+	"text",
+	"dm",
+	"voice",
+	"group_dm",
+	"category",
+	"announcement",
+	"announcement_thread",
+	"public_thread",
+	"private_thread",
+	"stage",
+	"directory",
+	"forum",
+	"media",
+}
+
+type ValidationRegistry interface {
+	RegisterValidation(
+		tag string,
+		fn validator.Func,
+	) error
+	RegisterStructValidation(
+		fn validator.StructLevelFunc,
+		types ...any,
+	)
+}
+
+func (v *ConfigValidator) RegisterValidation(
+	tag string,
+	fn validator.Func,
+) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.validate.RegisterValidation(tag, fn)
+}
+
+func (v *ConfigValidator) RegisterStructValidation(
+	fn validator.StructLevelFunc,
+	types ...any,
+) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.validate.RegisterStructValidation(fn, types...)
+}
+
+func registerBuiltinRules(validate *validator.Validate) {
+	rules := map[string]validator.Func{
+		TagSnowflake:   validateSnowflake,
+		TagHexColor:    validateHexColor,
+		TagDuration:    validateDuration,
+		TagRegexp:      validateRegexp,
+		TagEmoji:       validateEmoji,
+		TagChannelType: validateChannelType,
+		TagCron:        validateCron,
+		TagByteSize:    validateByteSize,
+		TagPercentage:  validatePercentage,
+	}
+	for tag, fn := range rules {
+		_ = validate.RegisterValidation(tag, fn)
+	}
+	registerScalarTypes(validate)
+}
+
+func validateSnowflake(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		return snowflakeRe.MatchString(field.String())
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return snowflakeRe.MatchString(strconv.FormatUint(field.Uint(), 10))
+	case reflect.Int, reflect.Int64:
+		return snowflakeRe.MatchString(strconv.FormatInt(field.Int(), 10))
+	}
+	return false
+}
+
+func validateHexColor(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		return hexColorRe.MatchString(field.String())
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return field.Int() >= 0 && field.Int() <= MaxColorValue
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return field.Uint() <= MaxColorValue
+	}
+	return false
+}
+
+func validateDuration(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Type() == durationType {
+		return true
+	}
+	if field.Kind() != reflect.String {
+		return false
+	}
+	_, err := time.ParseDuration(field.String())
+	return err == nil
+}
+
+func validateRegexp(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	_, err := regexp.Compile(field.String())
+	return err == nil
+}
+
+func validateEmoji(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	return IsEmoji(field.String())
+}
+
+func validateCron(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	_, err := NewCron(field.String())
+	return err == nil
+}
+
+func validateByteSize(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		_, err := ParseByteSize(field.String())
+		return err == nil
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return field.Int() >= 0
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func validatePercentage(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		_, err := ParsePercentage(field.String())
+		return err == nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() >= 0 && field.Int() <= 100
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint() <= 100
+	case reflect.Float32, reflect.Float64:
+		return field.Float() >= 0 && field.Float() <= 100
+	}
+	return false
+}
+
+func validateChannelType(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		value := strings.ToLower(field.String())
+		for _, t := range ChannelTypes {
+			if t == value {
+				return true
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return isDiscordChannelType(field.Int())
+	}
+	return false
+}
+
+func isDiscordChannelType(value int64) bool {
+	switch value {
+	case 0, 1, 2, 3, 4, 5, 10, 11, 12, 13, 14, 15, 16:
+		return true
+	}
+	return false
+}
+
+func IsEmoji(value string) bool {
+	if value == "" {
+		return false
+	}
+	if customEmojiRe.MatchString(value) {
+		return true
+	}
+
+	pictographic := false
+	for _, r := range value {
+		switch {
+		case r == '\u200d' || r == '\ufe0f':
+		case r == '\u20e3':
+			pictographic = true
+		case r == '#' || r == '*' || (r >= '0' && r <= '9'):
+		case r >= 0x1F000 && r <= 0x1FAFF,
+			r >= 0x2600 && r <= 0x27BF,
+			r >= 0x2300 && r <= 0x23FF,
+			r >= 0x2B00 && r <= 0x2BFF,
+			r >= 0xE0020 && r <= 0xE007F,
+			r == 0x00A9 || r == 0x00AE || r == 0x203C || r == 0x2049 || r == 0x2122 || r == 0x2139:
+			pictographic = true
+		default:
+			return false
+		}
+	}
+	return pictographic
+}
@@ -0,0 +1,117 @@
+package config_manager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"DiscordBotAgent/internal/core/zap_logger"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type DiscordRulesConfig struct {
+	GuildID     string        `yaml:"guild_id" validate:"snowflake"`
+	Roles       []string      `yaml:"roles" validate:"dive,snowflake"`
+	Color       string        `yaml:"color" validate:"hexcolor"`
+	ColorInt    int           `yaml:"color_int" validate:"hexcolor"`
+	Cooldown    string        `yaml:"cooldown" validate:"duration"`
+	Timeout     time.Duration `yaml:"timeout" validate:"duration"`
+	Pattern     string        `yaml:"pattern" validate:"regexp"`
+	Reaction    string        `yaml:"reaction" validate:"emoji"`
+	ChannelType string        `yaml:"channel_type" validate:"channel_type"`
+}
+
+func validDiscordRulesConfig() DiscordRulesConfig {
+	return DiscordRulesConfig{
+		GuildID:     "1074340840000000000",
+		Roles:       []string{"1129747578100000000"},
+		Color:       "#ff0000",
+		ColorInt:    0x00ff00,
+		Cooldown:    "1m30s",
+		Timeout:     5 * time.Second,
+		Pattern:     `^!\w+$`,
+		Reaction:    "👍",
+		ChannelType: "forum",
+	}
+}
+
+func TestBuiltinRules_Valid(t *testing.T) {
+	logger, _ := zap_logger.New()
+	v := NewValidator(logger)
+
+	if err := v.Validate("rules", validDiscordRulesConfig()); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	cfg := validDiscordRulesConfig()
+	cfg.Reaction = "<a:party:1129747578100000000>"
+	if err := v.Validate("rules", cfg); err != nil {
+		t.Errorf("expected custom emoji to be valid, got: %v", err)
+	}
+}
+
+func TestBuiltinRules_Invalid(t *testing.T) {
+	logger, _ := zap_logger.New()
+	v := NewValidator(logger)
+
+	cases := map[string]func(c *DiscordRulesConfig){
+		"guild_id":     func(c *DiscordRulesConfig) { c.GuildID = "abc" },
+		"roles[0]":     func(c *DiscordRulesConfig) { c.Roles = []string{"123"} },
+		"color":        func(c *DiscordRulesConfig) { c.Color = "red" },
+		"color_int":    func(c *DiscordRulesConfig) { c.ColorInt = 0x1000000 },
+		"cooldown":     func(c *DiscordRulesConfig) { c.Cooldown = "soon" },
+		"pattern":      func(c *DiscordRulesConfig) { c.Pattern = "([" },
+		"reaction":     func(c *DiscordRulesConfig) { c.Reaction = "123" },
+		"channel_type": func(c *DiscordRulesConfig) { c.ChannelType = "lobby" },
+	}
+
+	for path, mutate := range cases {
+		cfg := validDiscordRulesConfig()
+		mutate(&cfg)
+
+		fields := errorFields(v.Validate("rules", cfg))
+		if len(fields) != 1 || fields[0].Path != path {
+			t.Errorf("%s: expected single error at path, got %+v", path, fields)
+		}
+	}
+}
+
+type CustomRuleConfig struct {
+	Prefix string `yaml:"prefix" validate:"bang_prefix"`
+	Min    int    `yaml:"min"`
+	Max    int    `yaml:"max"`
+}
+
+func TestRegisterValidation_CustomTagAndStructRule(t *testing.T) {
+	m, _, _ := setupTestManager(t)
+	defer m.Close()
+
+	registry := m.Validations()
+	err := registry.RegisterValidation(
+		"bang_prefix", func(fl validator.FieldLevel) bool {
+			return len(fl.Field().String()) > 0 && fl.Field().String()[0] == '!'
+		},
+	)
+	if err != nil {
+		t.Fatalf("RegisterValidation() error: %v", err)
+	}
+	registry.RegisterStructValidation(
+		func(sl validator.StructLevel) {
+			cfg := sl.Current().Interface().(CustomRuleConfig)
+			if cfg.Min > cfg.Max {
+				sl.ReportError(cfg.Min, "min", "Min", "ltefield", "max")
+			}
+		}, CustomRuleConfig{},
+	)
+
+	err = m.validator.Validate("custom", CustomRuleConfig{Prefix: "?", Min: 5, Max: 1})
+
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(vErr.Fields) != 2 {
+		t.Fatalf("expected 2 field errors, got %+v", vErr.Fields)
+	}
+}
@@ -0,0 +1,504 @@
+package config_manager
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+)
+
+// Rich scalar types for module configs; each one is a plain YAML scalar in files and API responses.
+
+type Duration time.Duration
+
+type ByteSize int64
+
+type Color uint32
+
+type Snowflake string
+
+type Emoji string
+
+type Percentage float64
+
+type Regexp struct {
+	source string
+	re     *regexp.Regexp
+}
+
+type Cron struct {
+	source   string
+	schedule *cronSchedule
+}
+
+type schemaProvider interface {
+	ConfigSchema() map[string]any
+}
+
+// registerScalarTypes lets validate tags see the underlying value, so `validate:"required,gte=1m"` works on a Duration.
+func registerScalarTypes(validate *validator.Validate) {
+	validate.RegisterCustomTypeFunc(
+		func(v reflect.Value) any {
+			switch value := v.Interface().(type) {
+			case Duration:
+				return time.Duration(value)
+			case ByteSize:
+				return int64(value)
+			case Color:
+				return int64(value)
+			case Snowflake:
+				return string(value)
+			case Emoji:
+				return string(value)
+			case Percentage:
+				return float64(value)
+			case Regexp:
+				return value.source
+			case Cron:
+				return value.source
+			}
+			return nil
+		},
+		Duration(0), ByteSize(0), Color(0), Snowflake(""), Emoji(""), Percentage(0), Regexp{}, Cron{},
+	)
+}
+
+func scalarError(
+	node *yaml.Node,
+	kind string,
+	err error,
+) error {
+	return fmt.Errorf("line %d: invalid %s %q: %w", node.Line, kind, node.Value, err)
+}
+
+func scalarNode(
+	node *yaml.Node,
+	kind string,
+) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: %s must be a scalar", node.Line, kind)
+	}
+	return nil
+}
+
+// compiledCache keeps compiled patterns across reloads so unchanged regexps and schedules are not rebuilt.
+type compiledCache[T any] struct {
+	mu    sync.Mutex
+	items map[string]T
+}
+
+func (c *compiledCache[T]) get(
+	source string,
+	compile func(string) (T, error),
+) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if item, ok := c.items[source]; ok {
+		return item, nil
+	}
+	item, err := compile(source)
+	if err != nil {
+		return item, err
+	}
+	if c.items == nil || len(c.items) >= CompiledCacheSize {
+		c.items = make(map[string]T)
+	}
+	c.items[source] = item
+	return item, nil
+}
+
+var (
+	regexpCache compiledCache[*regexp.Regexp]
+	cronCache   compiledCache[*cronSchedule]
+)
+
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// String drops zero trailing units, so 5m is written as "5m" instead of "5m0s".
+func (d Duration) String() string {
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func (d Duration) MarshalYAML() (any, error) {
+	return d.String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "duration"); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return scalarError(node, "duration", err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (Duration) ConfigSchema() map[string]any {
+	return map[string]any{"type": "string", "pattern": DurationPattern}
+}
+
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3},
+	{"B", 1},
+}
+
+// ParseByteSize accepts a plain byte count or a number with an SI (KB = 1000) or IEC (KiB = 1024) unit.
+func ParseByteSize(value string) (ByteSize, error) {
+	s := strings.TrimSpace(value)
+	for _, u := range byteUnits {
+		if len(s) <= len(u.suffix) || !strings.EqualFold(s[len(s)-len(u.suffix):], u.suffix) {
+			continue
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(s[:len(s)-len(u.suffix)]), 64)
+		if err != nil {
+			return 0, err
+		}
+		return byteSizeOf(number * float64(u.size))
+	}
+
+	number, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a size such as 512KiB or 10MB")
+	}
+	return byteSizeOf(number)
+}
+
+func byteSizeOf(bytes float64) (ByteSize, error) {
+	if bytes < 0 || bytes > math.MaxInt64 || bytes != math.Trunc(bytes) {
+		return 0, fmt.Errorf("size must be a whole, non-negative number of bytes")
+	}
+	return ByteSize(bytes), nil
+}
+
+func (b ByteSize) Bytes() int64 {
+	return int64(b)
+}
+
+// String uses the largest unit that represents the size exactly.
+func (b ByteSize) String() string {
+	for _, u := range byteUnits {
+		if b != 0 && int64(b)%u.size == 0 {
+			return strconv.FormatInt(int64(b)/u.size, 10) + u.suffix
+		}
+	}
+	return "0B"
+}
+
+func (b ByteSize) MarshalYAML() (any, error) {
+	return b.String(), nil
+}
+
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "byte size"); err != nil {
+		return err
+	}
+	parsed, err := ParseByteSize(node.Value)
+	if err != nil {
+		return scalarError(node, "byte size", err)
+	}
+	*b = parsed
+	return nil
+}
+
+func (ByteSize) ConfigSchema() map[string]any {
+	return map[string]any{"type": []any{"string", "integer"}, "pattern": ByteSizePattern, "minimum": 0}
+}
+
+// ParseColor accepts #rgb and #rrggbb, with a #, 0x or no prefix.
+func ParseColor(value string) (Color, error) {
+	m := hexColorRe.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, fmt.Errorf("expected #rrggbb, #rgb or an integer")
+	}
+	hex := m[2]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, err
+	}
+	return Color(n), nil
+}
+
+// Int returns the value used by Discord embeds.
+func (c Color) Int() int {
+	return int(c)
+}
+
+func (c Color) String() string {
+	return fmt.Sprintf("#%06x", uint32(c))
+}
+
+func (c Color) MarshalYAML() (any, error) {
+	return c.String(), nil
+}
+
+func (c *Color) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "color"); err != nil {
+		return err
+	}
+	if node.Tag == "!!int" {
+		n, err := strconv.ParseUint(node.Value, 0, 64)
+		if err != nil || n > MaxColorValue {
+			return scalarError(node, "color", fmt.Errorf("color must be between 0 and 0xFFFFFF"))
+		}
+		*c = Color(n)
+		return nil
+	}
+
+	parsed, err := ParseColor(node.Value)
+	if err != nil {
+		return scalarError(node, "color", err)
+	}
+	*c = parsed
+	return nil
+}
+
+func (Color) ConfigSchema() map[string]any {
+	return map[string]any{
+		"type":    []any{"string", "integer"},
+		"pattern": HexColorPattern,
+		"minimum": 0,
+		"maximum": MaxColorValue,
+	}
+}
+
+func (s Snowflake) String() string {
+	return string(s)
+}
+
+func (s Snowflake) Uint64() uint64 {
+	n, _ := strconv.ParseUint(string(s), 10, 64)
+	return n
+}
+
+func (s *Snowflake) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "snowflake"); err != nil {
+		return err
+	}
+	if node.Value != "" && !snowflakeRe.MatchString(node.Value) {
+		return scalarError(node, "snowflake", fmt.Errorf("expected a Discord ID of 17-20 digits"))
+	}
+	*s = Snowflake(node.Value)
+	return nil
+}
+
+func (Snowflake) ConfigSchema() map[string]any {
+	return map[string]any{"type": []any{"string", "integer"}, "pattern": SnowflakePattern}
+}
+
+func (e Emoji) String() string {
+	return string(e)
+}
+
+func (e Emoji) IsCustom() bool {
+	return customEmojiRe.MatchString(string(e))
+}
+
+// APIName returns the form expected by the reaction endpoints: name:id for custom emojis, the emoji itself otherwise.
+func (e Emoji) APIName() string {
+	if !e.IsCustom() {
+		return string(e)
+	}
+	s := strings.Trim(string(e), "<>")
+	s = strings.TrimPrefix(s, "a:")
+	return strings.TrimPrefix(s, ":")
+}
+
+func (e *Emoji) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "emoji"); err != nil {
+		return err
+	}
+	if node.Value != "" && !IsEmoji(node.Value) {
+		return scalarError(node, "emoji", fmt.Errorf("expected a unicode or custom Discord emoji"))
+	}
+	*e = Emoji(node.Value)
+	return nil
+}
+
+func (Emoji) ConfigSchema() map[string]any {
+	return map[string]any{"type": "string"}
+}
+
+// ParsePercentage accepts "25%", "12.5%" or a plain number between 0 and 100.
+func ParsePercentage(value string) (Percentage, error) {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number such as 25 or 25%%")
+	}
+	if n < 0 || n > 100 {
+		return 0, fmt.Errorf("percentage must be between 0 and 100")
+	}
+	return Percentage(n), nil
+}
+
+// Fraction returns the percentage as 0..1.
+func (p Percentage) Fraction() float64 {
+	return float64(p) / 100
+}
+
+func (p Percentage) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "%"
+}
+
+func (p Percentage) MarshalYAML() (any, error) {
+	return p.String(), nil
+}
+
+func (p *Percentage) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "percentage"); err != nil {
+		return err
+	}
+	parsed, err := ParsePercentage(node.Value)
+	if err != nil {
+		return scalarError(node, "percentage", err)
+	}
+	*p = parsed
+	return nil
+}
+
+func (Percentage) ConfigSchema() map[string]any {
+	return map[string]any{
+		"type":    []any{"string", "number"},
+		"pattern": PercentagePattern,
+		"minimum": 0,
+		"maximum": 100,
+	}
+}
+
+func MustRegexp(source string) Regexp {
+	r, err := NewRegexp(source)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func NewRegexp(source string) (Regexp, error) {
+	if source == "" {
+		return Regexp{}, nil
+	}
+	re, err := regexpCache.get(source, regexp.Compile)
+	if err != nil {
+		return Regexp{}, err
+	}
+	return Regexp{source: source, re: re}, nil
+}
+
+// Regexp returns the compiled expression, or nil when the field is empty.
+func (r Regexp) Regexp() *regexp.Regexp {
+	return r.re
+}
+
+// MatchString reports false for an empty expression.
+func (r Regexp) MatchString(s string) bool {
+	return r.re != nil && r.re.MatchString(s)
+}
+
+func (r Regexp) String() string {
+	return r.source
+}
+
+func (r Regexp) IsZero() bool {
+	return r.source == ""
+}
+
+func (r Regexp) MarshalYAML() (any, error) {
+	return r.source, nil
+}
+
+func (r *Regexp) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "regexp"); err != nil {
+		return err
+	}
+	parsed, err := NewRegexp(node.Value)
+	if err != nil {
+		return scalarError(node, "regexp", err)
+	}
+	*r = parsed
+	return nil
+}
+
+func (Regexp) ConfigSchema() map[string]any {
+	return map[string]any{"type": "string", "format": "regex"}
+}
+
+func MustCron(source string) Cron {
+	c, err := NewCron(source)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func NewCron(source string) (Cron, error) {
+	if source == "" {
+		return Cron{}, nil
+	}
+	schedule, err := cronCache.get(source, parseCron)
+	if err != nil {
+		return Cron{}, err
+	}
+	return Cron{source: source, schedule: schedule}, nil
+}
+
+// Next returns the first activation after t, or the zero time for an empty schedule.
+func (c Cron) Next(t time.Time) time.Time {
+	if c.schedule == nil {
+		return time.Time{}
+	}
+	return c.schedule.next(t)
+}
+
+func (c Cron) String() string {
+	return c.source
+}
+
+func (c Cron) IsZero() bool {
+	return c.source == ""
+}
+
+func (c Cron) MarshalYAML() (any, error) {
+	return c.source, nil
+}
+
+func (c *Cron) UnmarshalYAML(node *yaml.Node) error {
+	if err := scalarNode(node, "cron expression"); err != nil {
+		return err
+	}
+	parsed, err := NewCron(node.Value)
+	if err != nil {
+		return scalarError(node, "cron expression", err)
+	}
+	*c = parsed
+	return nil
+}
+
+func (Cron) ConfigSchema() map[string]any {
+	return map[string]any{"type": "string", "pattern": CronPattern}
+}
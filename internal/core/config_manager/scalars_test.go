@@ -0,0 +1,254 @@
+package config_manager
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"DiscordBotAgent/internal/core/zap_logger"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ScalarConfig struct {
+	Timeout  Duration   `yaml:"timeout" validate:"required,gte=1s"`
+	MaxSize  ByteSize   `yaml:"max_size" validate:"lte=10485760"`
+	Color    Color      `yaml:"color" validate:"hexcolor"`
+	Channel  Snowflake  `yaml:"channel" validate:"required"`
+	Filter   Regexp     `yaml:"filter"`
+	Schedule Cron       `yaml:"schedule"`
+	Reaction Emoji      `yaml:"reaction"`
+	Share    Percentage `yaml:"share" validate:"lte=50"`
+}
+
+func scalarTemplate() ScalarConfig {
+	return ScalarConfig{
+		Timeout:  Duration(90 * time.Second),
+		MaxSize:  ByteSize(5 << 20),
+		Color:    Color(0xff8800),
+		Channel:  Snowflake("123456789012345678"),
+		Filter:   MustRegexp(`^!(\w+)`),
+		Schedule: MustCron("*/15 9-17 * * mon-fri"),
+		Reaction: Emoji("<:ok:123456789012345678>"),
+		Share:    Percentage(12.5),
+	}
+}
+
+func TestScalars_RoundTripThroughPlaceholder(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+
+	template := scalarTemplate()
+	if err := m.Register("scalars", template, nil); !errors.Is(err, ErrPlaceholderCreated) {
+		t.Fatalf("expected placeholder, got %v", err)
+	}
+	m.Close()
+
+	logger, _ := zap_logger.New()
+	reloaded, err := New(logger, defaultPath, mergePath)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer reloaded.Close()
+
+	if err := reloaded.Register("scalars", ScalarConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	got := reloaded.Get("scalars").(ScalarConfig)
+	if got.Timeout != template.Timeout || got.MaxSize != template.MaxSize || got.Color != template.Color ||
+		got.Channel != template.Channel || got.Reaction != template.Reaction || got.Share != template.Share {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, template)
+	}
+	if got.Filter.String() != template.Filter.String() || got.Schedule.String() != template.Schedule.String() {
+		t.Errorf("pattern round trip mismatch: %q %q", got.Filter, got.Schedule)
+	}
+	if got.Filter.Regexp() != template.Filter.Regexp() {
+		t.Error("expected the compiled regexp to be reused from the cache")
+	}
+	if !got.Filter.MatchString("!ping") {
+		t.Error("expected compiled filter to match")
+	}
+}
+
+func TestScalars_PlaceholderIsReadable(t *testing.T) {
+	data, err := documentedYaml(scalarTemplate())
+	if err != nil {
+		t.Fatalf("documentedYaml() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"timeout: 1m30s",
+		"max_size: 5MiB",
+		`color: '#ff8800'`,
+		"share: 12.5%",
+		"schedule: '*/15 9-17 * * mon-fri'",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in placeholder:\n%s", want, data)
+		}
+	}
+}
+
+func TestScalars_ParseForms(t *testing.T) {
+	var cfg struct {
+		Timeout Duration   `yaml:"timeout"`
+		Size    ByteSize   `yaml:"size"`
+		Color   Color      `yaml:"color"`
+		Hex     Color      `yaml:"hex"`
+		Short   Color      `yaml:"short"`
+		Share   Percentage `yaml:"share"`
+		Channel Snowflake  `yaml:"channel"`
+		Emoji   Emoji      `yaml:"emoji"`
+	}
+	input := `
+timeout: 2h
+size: 1.5 KB
+color: 16711680
+hex: 0x00ff00
+short: "#00f"
+share: 30
+channel: 123456789012345678
+emoji: "<a:party:123456789012345678>"
+`
+	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	if cfg.Timeout.Std() != 2*time.Hour || cfg.Timeout.String() != "2h" {
+		t.Errorf("unexpected timeout %v", cfg.Timeout)
+	}
+	if cfg.Size.Bytes() != 1500 {
+		t.Errorf("unexpected size %d", cfg.Size)
+	}
+	if cfg.Color.Int() != 0xff0000 || cfg.Hex.Int() != 0x00ff00 || cfg.Short.Int() != 0x0000ff {
+		t.Errorf("unexpected colors %v %v %v", cfg.Color, cfg.Hex, cfg.Short)
+	}
+	if cfg.Share.Fraction() != 0.3 {
+		t.Errorf("unexpected share %v", cfg.Share)
+	}
+	if cfg.Channel.Uint64() != 123456789012345678 {
+		t.Errorf("unexpected channel %v", cfg.Channel)
+	}
+	if !cfg.Emoji.IsCustom() || cfg.Emoji.APIName() != "party:123456789012345678" {
+		t.Errorf("unexpected emoji %v (%s)", cfg.Emoji, cfg.Emoji.APIName())
+	}
+}
+
+func TestScalars_RejectInvalidValues(t *testing.T) {
+	cases := map[string]struct {
+		target any
+		input  string
+	}{
+		"duration":   {new(Duration), "soon"},
+		"byte size":  {new(ByteSize), "12 parsecs"},
+		"color":      {new(Color), "'#12345g'"},
+		"snowflake":  {new(Snowflake), "abc"},
+		"regexp":     {new(Regexp), "'(unclosed'"},
+		"cron":       {new(Cron), "'61 * * * *'"},
+		"emoji":      {new(Emoji), "smile"},
+		"percentage": {new(Percentage), "150%"},
+	}
+
+	for name, tc := range cases {
+		t.Run(
+			name, func(t *testing.T) {
+				err := yaml.Unmarshal([]byte(tc.input), tc.target)
+				if err == nil || !strings.Contains(err.Error(), "invalid "+name) {
+					t.Errorf("expected invalid %s error, got %v", name, err)
+				}
+			},
+		)
+	}
+}
+
+func TestScalars_ValidatorSeesUnderlyingValue(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(
+		t,
+		filepath.Join(defaultPath, "limits.yaml"),
+		"timeout: 500ms\nmax_size: 20MiB\ncolor: '#fff'\nchannel: '123456789012345678'\nshare: 75%\n",
+	)
+
+	err := m.Register("limits", ScalarConfig{}, nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	paths := make(map[string]bool)
+	for _, fe := range verr.Fields {
+		paths[fe.Path] = true
+	}
+	for _, want := range []string{"timeout", "max_size", "share"} {
+		if !paths[want] {
+			t.Errorf("expected error for %s, got %+v", want, verr.Fields)
+		}
+	}
+	if paths["color"] || paths["channel"] {
+		t.Errorf("unexpected errors %+v", verr.Fields)
+	}
+}
+
+func TestScalars_TagsOnPlainFields(t *testing.T) {
+	v := NewValidator(zap_logger.NewNop())
+
+	type plain struct {
+		Schedule string `yaml:"schedule" validate:"cron"`
+		Size     string `yaml:"size" validate:"bytesize"`
+		Share    string `yaml:"share" validate:"percentage"`
+		Ratio    int    `yaml:"ratio" validate:"percentage"`
+	}
+
+	if err := v.Validate("plain", plain{Schedule: "@daily", Size: "64KiB", Share: "10%", Ratio: 100}); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+	if err := v.Validate("plain", plain{Schedule: "* * *", Size: "lots", Share: "101%", Ratio: -1}); err == nil {
+		t.Error("expected validation errors")
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	from := time.Date(2026, 3, 6, 17, 50, 0, 0, time.UTC) // Friday
+
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 9-17 * * mon-fri", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 12 10 * 5", time.Date(2026, 3, 10, 12, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		got := MustCron(tc.spec).Next(from)
+		if !got.Equal(tc.want) {
+			t.Errorf("Next(%q) = %v, want %v", tc.spec, got, tc.want)
+		}
+	}
+}
+
+func TestSchema_ScalarTypes(t *testing.T) {
+	schema := GenerateSchema("scalars", reflect.TypeOf(ScalarConfig{}))
+	props := schema["properties"].(map[string]any)
+
+	timeout := props["timeout"].(map[string]any)
+	if timeout["type"] != "string" || timeout["pattern"] != DurationPattern {
+		t.Errorf("unexpected duration schema: %v", timeout)
+	}
+	if _, ok := timeout["minimum"]; ok {
+		t.Errorf("bound rules must not leak into scalar schema: %v", timeout)
+	}
+	if props["filter"].(map[string]any)["format"] != "regex" {
+		t.Errorf("unexpected regexp schema: %v", props["filter"])
+	}
+	if props["share"].(map[string]any)["maximum"] != 100 {
+		t.Errorf("unexpected percentage schema: %v", props["share"])
+	}
+}
@@ -0,0 +1,338 @@
+package config_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func GenerateSchema(
+	name string,
+	t reflect.Type,
+) map[string]any {
+	schema := schemaForType(t)
+	if props, ok := schema["properties"].(map[string]any); ok {
+		props[KeySchemaVersion] = map[string]any{"type": "integer", "minimum": InitialSchemaVersion}
+	}
+	schema["$schema"] = JSONSchemaDraft
+	schema["title"] = name
+	return schema
+}
+
+func (m *Manager) Schema(name string) (map[string]any, error) {
+	m.mu.RLock()
+	meta, ok := m.registry[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrConfigNotFound
+	}
+
+	return GenerateSchema(name, meta.StructType), nil
+}
+
+func (m *Manager) schemaPath(name string) string {
+	return filepath.Clean(filepath.Join(m.pathDefault, DirSchemas, name+ExtensionSchema))
+}
+
+func (m *Manager) writeSchema(
+	name string,
+	t reflect.Type,
+) {
+	path := m.schemaPath(name)
+
+	data, err := json.MarshalIndent(GenerateSchema(name, t), "", "  ")
+	if err != nil {
+		m.log.Warn("failed to marshal json schema", zap.String("config", name), zap.Error(err))
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		m.log.Warn("failed to create schema directory", zap.String("path", path), zap.Error(err))
+		return
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		m.log.Warn("failed to write json schema", zap.String("path", path), zap.Error(err))
+		return
+	}
+
+	m.log.Debug("json schema written", zap.String("config", name), zap.String("path", path))
+}
+
+func schemaModeline(name string) string {
+	return fmt.Sprintf("# yaml-language-server: $schema=./%s/%s%s\n", DirSchemas, name, ExtensionSchema)
+}
+
+func schemaForType(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if provider, ok := reflect.Zero(t).Interface().(schemaProvider); ok {
+		return provider.ConfigSchema()
+	}
+
+	if t == durationType {
+		return map[string]any{
+			"type":    "string",
+			"pattern": DurationPattern,
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaForType(t.Elem())}
+	case reflect.Struct:
+		return schemaForStruct(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+func schemaForStruct(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := yamlKeyOf(field)
+		if key == "-" {
+			continue
+		}
+
+		prop := schemaForType(field.Type)
+		if desc := field.Tag.Get(TagDescription); desc != "" {
+			prop["description"] = desc
+		}
+		if example := field.Tag.Get(TagExample); example != "" {
+			prop["examples"] = []any{exampleValue(example)}
+		}
+		if applyValidateTag(prop, field.Type, field.Tag.Get("validate")) {
+			required = append(required, key)
+		}
+		properties[key] = prop
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func applyValidateTag(
+	schema map[string]any,
+	t reflect.Type,
+	tag string,
+) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	required := false
+	dived := false
+	target := schema
+	targetType := t
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		if name == "dive" {
+			items, ok := target["items"].(map[string]any)
+			if !ok {
+				items, ok = target["additionalProperties"].(map[string]any)
+			}
+			if !ok {
+				return required
+			}
+			target = items
+			targetType = elemType(targetType)
+			dived = true
+			continue
+		}
+
+		if name == "required" && !dived {
+			required = true
+			continue
+		}
+
+		applyRule(target, targetType, name, param)
+	}
+
+	return required
+}
+
+func applyRule(
+	schema map[string]any,
+	t reflect.Type,
+	name, param string,
+) {
+	kind := baseKind(t)
+	if _, ok := reflect.Zero(t).Interface().(schemaProvider); ok {
+		return
+	}
+
+	switch name {
+	case "gte", "min":
+		setBound(schema, kind, param, "minimum", "minLength", "minItems")
+	case "lte", "max":
+		setBound(schema, kind, param, "maximum", "maxLength", "maxItems")
+	case "gt":
+		if isNumericKind(kind) {
+			schema["exclusiveMinimum"] = parseNumber(param)
+		}
+	case "lt":
+		if isNumericKind(kind) {
+			schema["exclusiveMaximum"] = parseNumber(param)
+		}
+	case "len":
+		setBound(schema, kind, param, "minimum", "minLength", "minItems")
+		setBound(schema, kind, param, "maximum", "maxLength", "maxItems")
+	case "oneof":
+		values := strings.Fields(param)
+		enum := make([]any, 0, len(values))
+		for _, v := range values {
+			if isNumericKind(kind) {
+				enum = append(enum, parseNumber(v))
+			} else {
+				enum = append(enum, v)
+			}
+		}
+		schema["enum"] = enum
+	case "email":
+		schema["format"] = "email"
+	case "url", "uri":
+		schema["format"] = "uri"
+	case "hostname":
+		schema["format"] = "hostname"
+	case "ipv4", "ipv6":
+		schema["format"] = name
+	case "uuid":
+		schema["format"] = "uuid"
+	case TagSnowflake:
+		if kind == reflect.String {
+			schema["pattern"] = SnowflakePattern
+		}
+	case TagHexColor:
+		if kind == reflect.String {
+			schema["pattern"] = HexColorPattern
+		} else {
+			schema["minimum"] = 0
+			schema["maximum"] = MaxColorValue
+		}
+	case TagDuration:
+		schema["type"] = "string"
+		schema["pattern"] = DurationPattern
+	case TagRegexp:
+		schema["format"] = "regex"
+	case TagCron:
+		schema["pattern"] = CronPattern
+	case TagByteSize:
+		if kind == reflect.String {
+			schema["pattern"] = ByteSizePattern
+		} else {
+			schema["minimum"] = 0
+		}
+	case TagPercentage:
+		if kind == reflect.String {
+			schema["pattern"] = PercentagePattern
+		} else {
+			schema["minimum"] = 0
+			schema["maximum"] = 100
+		}
+	case TagChannelType:
+		if kind == reflect.String {
+			enum := make([]any, 0, len(ChannelTypes))
+			for _, t := range ChannelTypes {
+				enum = append(enum, t)
+			}
+			schema["enum"] = enum
+		}
+	}
+}
+
+func setBound(
+	schema map[string]any,
+	kind reflect.Kind,
+	param, numberKey, stringKey, arrayKey string,
+) {
+	switch {
+	case isNumericKind(kind):
+		schema[numberKey] = parseNumber(param)
+	case kind == reflect.String:
+		schema[stringKey] = parseNumber(param)
+	case kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map:
+		if kind == reflect.Map {
+			arrayKey = strings.Replace(arrayKey, "Items", "Properties", 1)
+		}
+		schema[arrayKey] = parseNumber(param)
+	}
+}
+
+func baseKind(t reflect.Type) reflect.Kind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind()
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+	}
+	return t
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func parseNumber(s string) any {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
@@ -0,0 +1,109 @@
+package config_manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type SchemaTestConfig struct {
+	Enabled *bool             `yaml:"enabled" validate:"required"`
+	Mode    string            `yaml:"mode" validate:"oneof=fast slow"`
+	Limit   int               `yaml:"limit" validate:"gte=1,lte=10"`
+	Tags    []string          `yaml:"tags" validate:"max=3,dive,min=2"`
+	Nested  SchemaTestNested  `yaml:"nested"`
+	Ignored string            `yaml:"-"`
+	Labels  map[string]string `yaml:"labels"`
+	Host    string            `yaml:"host" validate:"ip"`
+	Gateway string            `yaml:"gateway" validate:"ipv6"`
+}
+
+type SchemaTestNested struct {
+	Name string `yaml:"name" validate:"required"`
+}
+
+func TestGenerateSchema_MapsYamlAndValidateTags(t *testing.T) {
+	schema := GenerateSchema("schematest", reflect.TypeOf(SchemaTestConfig{}))
+
+	if schema["$schema"] != JSONSchemaDraft {
+		t.Errorf("unexpected $schema: %v", schema["$schema"])
+	}
+
+	props := schema["properties"].(map[string]any)
+	if _, ok := props["Ignored"]; ok {
+		t.Error("field with yaml:\"-\" must be skipped")
+	}
+	if _, ok := props["-"]; ok {
+		t.Error("field with yaml:\"-\" must be skipped")
+	}
+
+	required := schema["required"].([]string)
+	if len(required) != 1 || required[0] != "enabled" {
+		t.Errorf("expected required [enabled], got %v", required)
+	}
+
+	if props["enabled"].(map[string]any)["type"] != "boolean" {
+		t.Error("expected enabled to be boolean")
+	}
+
+	mode := props["mode"].(map[string]any)
+	if enum := mode["enum"].([]any); len(enum) != 2 || enum[0] != "fast" {
+		t.Errorf("unexpected enum: %v", enum)
+	}
+
+	limit := props["limit"].(map[string]any)
+	if limit["minimum"] != int64(1) || limit["maximum"] != int64(10) {
+		t.Errorf("unexpected limit bounds: %v", limit)
+	}
+
+	tags := props["tags"].(map[string]any)
+	if tags["maxItems"] != int64(3) {
+		t.Errorf("expected maxItems 3, got %v", tags["maxItems"])
+	}
+	if tags["items"].(map[string]any)["minLength"] != int64(2) {
+		t.Errorf("expected items minLength 2, got %v", tags["items"])
+	}
+
+	if format, ok := props["host"].(map[string]any)["format"]; ok {
+		t.Errorf("ip accepts both families and must not map to a format, got %v", format)
+	}
+	if props["gateway"].(map[string]any)["format"] != "ipv6" {
+		t.Errorf("expected ipv6 format, got %v", props["gateway"])
+	}
+
+	nested := props["nested"].(map[string]any)
+	if nested["additionalProperties"] != false {
+		t.Error("expected nested object to forbid additional properties")
+	}
+	if r := nested["required"].([]string); len(r) != 1 || r[0] != "name" {
+		t.Errorf("unexpected nested required: %v", r)
+	}
+}
+
+func TestRegister_WritesSchemaAndModeline(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	_ = m.Register("schemaplaceholder", TestConfig{}, nil)
+
+	data, err := os.ReadFile(filepath.Join(defaultPath, DirSchemas, "schemaplaceholder"+ExtensionSchema))
+	if err != nil {
+		t.Fatalf("schema file not written: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid json: %v", err)
+	}
+
+	placeholder, err := os.ReadFile(filepath.Join(defaultPath, "schemaplaceholder.yaml"))
+	if err != nil {
+		t.Fatalf("placeholder not written: %v", err)
+	}
+	if !strings.HasPrefix(string(placeholder), "# yaml-language-server: $schema=./.schemas/schemaplaceholder.schema.json") {
+		t.Errorf("placeholder missing schema modeline: %s", strings.SplitN(string(placeholder), "\n", 2)[0])
+	}
+}
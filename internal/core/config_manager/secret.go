@@ -0,0 +1,273 @@
+package config_manager
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var secretRefRe = regexp.MustCompile(`\$\{secret:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+type SecretResolver interface {
+	Resolve(name string) (string, bool)
+}
+
+type SecretRef struct {
+	Config string `json:"config"`
+	Key    string `json:"key"`
+	Secret string `json:"secret"`
+}
+
+type secretValue struct {
+	Name  string
+	Value string
+}
+
+type secretSet struct {
+	Refs   []SecretRef
+	values []secretValue
+	// paths maps each key that referenced a secret to its unresolved text, so values that
+	// decode to non-strings (ports, IDs) are masked too.
+	paths map[string]string
+}
+
+func (m *Manager) resolveSecrets(
+	name string,
+	data map[string]any,
+) (map[string]any, *secretSet, error) {
+	set := &secretSet{paths: make(map[string]string)}
+	var missing []FieldError
+
+	resolved := m.resolveSecretValue(name, cloneMap(data), "", set, &missing).(map[string]any)
+	if len(missing) > 0 {
+		return nil, nil, &ValidationError{
+			Config: name,
+			Fields: missing,
+			err:    fmt.Errorf("%w: unresolved secret references", ErrSecretNotFound),
+		}
+	}
+
+	sort.Slice(
+		set.Refs, func(i, j int) bool {
+			return set.Refs[i].Key < set.Refs[j].Key
+		},
+	)
+	return resolved, set, nil
+}
+
+func (m *Manager) resolveSecretValue(
+	name string,
+	value any,
+	yamlPath string,
+	set *secretSet,
+	missing *[]FieldError,
+) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			v[k] = m.resolveSecretValue(name, child, joinYamlPath(yamlPath, k), set, missing)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = m.resolveSecretValue(name, item, fmt.Sprintf("%s[%d]", yamlPath, i), set, missing)
+		}
+		return v
+	case string:
+		if !strings.Contains(v, "${secret:") {
+			return v
+		}
+		set.paths[yamlPath] = v
+		resolved := secretRefRe.ReplaceAllStringFunc(
+			v, func(ref string) string {
+				secret := secretRefRe.FindStringSubmatch(ref)[1]
+				set.Refs = append(set.Refs, SecretRef{Config: name, Key: yamlPath, Secret: secret})
+
+				resolved, ok := "", false
+				if m.secrets != nil {
+					resolved, ok = m.secrets.Resolve(secret)
+				}
+				if !ok {
+					*missing = append(
+						*missing, FieldError{
+							Path:    yamlPath,
+							Rule:    "secret",
+							Param:   secret,
+							Message: fmt.Sprintf("secret %q is not defined", secret),
+						},
+					)
+					return ref
+				}
+
+				set.add(secret, resolved)
+				return resolved
+			},
+		)
+		if secretRefRe.FindString(v) == v {
+			return secretScalar(resolved)
+		}
+		return resolved
+	}
+	return value
+}
+
+// secretScalar lets a value that is a single reference fill number and bool fields. Only
+// canonical forms are converted, so string fields still receive the secret unchanged.
+func secretScalar(s string) any {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && strconv.FormatInt(n, 10) == s {
+		return int(n)
+	}
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if strings.Trim(s, "-.0123456789") != "" {
+		return s
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == s {
+		return f
+	}
+	return s
+}
+
+func (s *secretSet) add(
+	name, value string,
+) {
+	if value == "" {
+		return
+	}
+	for _, sv := range s.values {
+		if sv.Value == value {
+			return
+		}
+	}
+	s.values = append(s.values, secretValue{Name: name, Value: value})
+	// Longer values first so a secret containing another one is masked as a whole.
+	sort.SliceStable(
+		s.values, func(i, j int) bool {
+			return len(s.values[i].Value) > len(s.values[j].Value)
+		},
+	)
+}
+
+func (s *secretSet) refs() []SecretRef {
+	if s == nil {
+		return nil
+	}
+	return s.Refs
+}
+
+func (s *secretSet) redact(text string) string {
+	if s == nil {
+		return text
+	}
+	for _, sv := range s.values {
+		text = strings.ReplaceAll(text, sv.Value, placeholderFor(sv.Name))
+	}
+	return text
+}
+
+func (s *secretSet) redactValue(value any) any {
+	return s.redactStrings(s.redactPaths("", value))
+}
+
+// redactPaths swaps every value at a key that referenced a secret back to its unresolved text.
+func (s *secretSet) redactPaths(
+	path string,
+	value any,
+) any {
+	if s == nil || len(s.paths) == 0 {
+		return value
+	}
+	if raw, ok := s.paths[path]; ok {
+		return raw
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[k] = s.redactPaths(joinYamlPath(path, k), child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = s.redactPaths(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+		return out
+	}
+	return value
+}
+
+func (s *secretSet) redactStrings(value any) any {
+	if s == nil || len(s.values) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[k] = s.redactStrings(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = s.redactStrings(item)
+		}
+		return out
+	case string:
+		return s.redact(v)
+	}
+	return value
+}
+
+// redactChanges masks old values with the secrets of the previous state and new values with
+// the current ones; resolved secret text from either side is masked anywhere.
+func redactChanges(
+	previous, current *secretSet,
+	changes []Change,
+) []Change {
+	values := mergeSecretSets(previous, current)
+	for i := range changes {
+		changes[i].Old = values.redactStrings(previous.redactPaths(changes[i].Path, changes[i].Old))
+		changes[i].New = values.redactStrings(current.redactPaths(changes[i].Path, changes[i].New))
+	}
+	return changes
+}
+
+func (s *secretSet) redactError(err error) error {
+	if s == nil || err == nil {
+		return err
+	}
+
+	msg := s.redact(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	if errors.Is(err, ErrConfigParse) {
+		return fmt.Errorf("%w: %s", ErrConfigParse, strings.TrimPrefix(msg, ErrConfigParse.Error()+": "))
+	}
+	return errors.New(msg)
+}
+
+func mergeSecretSets(sets ...*secretSet) *secretSet {
+	merged := &secretSet{}
+	for _, s := range sets {
+		if s == nil {
+			continue
+		}
+		for _, sv := range s.values {
+			merged.add(sv.Name, sv.Value)
+		}
+	}
+	return merged
+}
+
+func placeholderFor(name string) string {
+	return "${secret:" + name + "}"
+}
@@ -0,0 +1,164 @@
+package config_manager
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSecrets map[string]string
+
+func (f fakeSecrets) Resolve(name string) (string, bool) {
+	v, ok := f[name]
+	return v, ok
+}
+
+func TestRegister_ResolvesSecretReferences(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.secrets = fakeSecrets{"WEBHOOK_TOKEN": "s3cr3t-token"}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "secret.yaml"), "name: \"token=${secret:WEBHOOK_TOKEN}\"\ncount: 1\n")
+	if err := m.Register("secret", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	cfg := m.Get("secret").(TestConfig)
+	if cfg.Name != "token=s3cr3t-token" {
+		t.Errorf("secret not resolved, got %q", cfg.Name)
+	}
+
+	doc, err := m.Document("secret")
+	if err != nil {
+		t.Fatalf("Document() error: %v", err)
+	}
+	if doc.Effective["name"] != "token=${secret:WEBHOOK_TOKEN}" {
+		t.Errorf("effective value must be redacted, got %v", doc.Effective["name"])
+	}
+
+	info, _ := m.Info("secret")
+	if len(info.Secrets) != 1 || info.Secrets[0].Key != "name" || info.Secrets[0].Secret != "WEBHOOK_TOKEN" {
+		t.Errorf("unexpected secret refs: %+v", info.Secrets)
+	}
+
+	rev, err := m.Revision("secret", 1)
+	if err != nil {
+		t.Fatalf("Revision() error: %v", err)
+	}
+	if strings.Contains(rev.Content, "s3cr3t-token") {
+		t.Errorf("revision must not contain resolved secret: %s", rev.Content)
+	}
+}
+
+func TestRegister_MissingSecretReported(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.secrets = fakeSecrets{}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "missing.yaml"), "name: ${secret:UNKNOWN}\n")
+	err := m.Register("missing", TestConfig{}, nil)
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got %v", err)
+	}
+
+	fields := errorFields(err)
+	if len(fields) != 1 || fields[0].Rule != "secret" || fields[0].Path != "name" || fields[0].Line != 1 {
+		t.Errorf("unexpected field errors: %+v", fields)
+	}
+}
+
+func TestRegister_DecodeErrorRedactsSecret(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.secrets = fakeSecrets{"COUNT": "s3cr3t-count"}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "typed.yaml"), "name: base\ncount: ${secret:COUNT}\n")
+	err := m.Register("typed", TestConfig{}, nil)
+	if err == nil {
+		t.Fatal("expected decode error")
+	}
+	if strings.Contains(err.Error(), "s3cr3t-count") {
+		t.Errorf("error must not contain resolved secret: %v", err)
+	}
+}
+
+func TestPatchMerge_RedactsSecretsInChanges(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.secrets = fakeSecrets{"NAME": "s3cr3t-name"}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "patched.yaml"), "name: base\n")
+	if err := m.Register("patched", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	doc, err := m.PatchMerge(context.Background(), "patched", map[string]any{"name": "${secret:NAME}"})
+	if err != nil {
+		t.Fatalf("PatchMerge() error: %v", err)
+	}
+	if doc.Effective["name"] != "${secret:NAME}" || doc.Merge["name"] != "${secret:NAME}" {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+
+	changes := redactChanges(
+		nil,
+		&secretSet{values: []secretValue{{Name: "NAME", Value: "s3cr3t-name"}}},
+		[]Change{{Path: "name", Type: ChangeModified, Old: "base", New: "s3cr3t-name"}},
+	)
+	if changes[0].New != "${secret:NAME}" {
+		t.Errorf("change must be redacted, got %v", changes[0].New)
+	}
+}
+
+func TestRedact_NonStringSecretsMaskedByKey(t *testing.T) {
+	m, defaultPath, mergePath := setupTestManager(t)
+	defer m.Close()
+	m.secrets = fakeSecrets{"COUNT": "42"}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "numeric.yaml"), "name: base\ncount: 7\n")
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.numeric.yaml"), "count: ${secret:COUNT}\n")
+	events := make(chan UpdateEvent, 2)
+	err := m.Register(
+		"numeric",
+		TestConfig{},
+		nil,
+		WithChangeCallback(
+			func(
+				ctx context.Context,
+				event UpdateEvent,
+			) {
+				events <- event
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	<-events
+	if got := m.Get("numeric").(TestConfig).Count; got != 42 {
+		t.Fatalf("secret not resolved, got %d", got)
+	}
+
+	doc, err := m.Document("numeric")
+	if err != nil {
+		t.Fatalf("Document() error: %v", err)
+	}
+	if doc.Effective["count"] != "${secret:COUNT}" {
+		t.Errorf("numeric secret must be redacted, got %v", doc.Effective["count"])
+	}
+
+	writeYamlFile(t, filepath.Join(mergePath, "MERGE.numeric.yaml"), "count: 9\n")
+	m.reloadConfig(context.Background(), "numeric")
+
+	select {
+	case event := <-events:
+		if len(event.Changes) != 1 || event.Changes[0].Old != "${secret:COUNT}" || event.Changes[0].New != 9 {
+			t.Errorf("unexpected changes: %+v", event.Changes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("change callback was not called")
+	}
+}
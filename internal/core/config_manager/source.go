@@ -0,0 +1,248 @@
+package config_manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"DiscordBotAgent/pkg/ctxtrace"
+
+	"go.uber.org/zap"
+)
+
+// ConfigSource supplies the defaults directory; Fetch returns a nil snapshot while current is still served.
+type ConfigSource interface {
+	Name() string
+	Fetch(
+		ctx context.Context,
+		current string,
+	) (*SourceSnapshot, error)
+}
+
+type SourceSnapshot struct {
+	Version string
+	Files   map[string][]byte
+}
+
+type sourceManifest struct {
+	Source   string    `json:"source"`
+	Version  string    `json:"version"`
+	Files    []string  `json:"files"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// ParseSource builds a source from a spec: an http(s) URL, file:<dir> or git:<repo>[?ref=<ref>&path=<dir>].
+func ParseSource(spec string) (ConfigSource, error) {
+	switch {
+	case spec == "":
+		return nil, nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewHTTPSource(spec, nil), nil
+	case strings.HasPrefix(spec, "file:"):
+		return NewFileSource(strings.TrimPrefix(spec, "file:")), nil
+	case strings.HasPrefix(spec, "git:"):
+		dir, rawQuery, _ := strings.Cut(strings.TrimPrefix(spec, "git:"), "?")
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrSourceSpec, spec, err)
+		}
+		return NewGitSource(dir, query.Get("ref"), query.Get("path")), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSourceSpec, spec)
+}
+
+func (m *Manager) sourceLoop() {
+	ticker := time.NewTicker(m.sourceInterval)
+	defer ticker.Stop()
+
+	m.log.Info(
+		"config source polling enabled",
+		zap.String("source", m.source.Name()),
+		zap.Duration("interval", m.sourceInterval),
+	)
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx := ctxtrace.WithCorrelationID(context.Background(), m.generateTraceID())
+		if err := m.syncSource(ctx); err != nil {
+			m.log.WithCtx(ctx).Error(
+				"config source sync failed, keeping local copy",
+				zap.String("source", m.source.Name()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// syncSource mirrors the source snapshot into the defaults directory; the watcher then reloads changed configs.
+func (m *Manager) syncSource(ctx context.Context) error {
+	m.sourceMu.Lock()
+	defer m.sourceMu.Unlock()
+
+	snap, err := m.source.Fetch(ctx, m.sourceVersion)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", m.source.Name(), err)
+	}
+	if snap == nil {
+		m.log.WithCtx(ctx).Debug("config source unchanged", zap.String("source", m.source.Name()))
+		return nil
+	}
+
+	targets := make(map[string]string, len(snap.Files))
+	for rel := range snap.Files {
+		clean, ok, err := sourcePath(rel)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			m.log.WithCtx(ctx).Debug("skipping non-config source entry", zap.String("path", rel))
+			continue
+		}
+		targets[clean] = rel
+	}
+
+	previous := m.readSourceManifest()
+	owned := make(map[string]bool, len(previous.Files))
+	for _, clean := range previous.Files {
+		owned[clean] = true
+	}
+	firstSync := previous.Version == "" && len(previous.Files) == 0
+
+	written := 0
+	var files, conflicts, backups []string
+	for clean, rel := range targets {
+		path := filepath.Join(m.pathDefault, clean)
+		current, err := os.ReadFile(path)
+		switch {
+		case err == nil && bytes.Equal(current, snap.Files[rel]):
+			files = append(files, clean)
+			continue
+		case err == nil && !owned[clean] && !isPlaceholder(current):
+			if !firstSync {
+				conflicts = append(conflicts, clean)
+				continue
+			}
+			backup := nextBackupPath(path)
+			if err := writeFileAtomic(backup, current); err != nil {
+				return fmt.Errorf("back up %s: %w", clean, err)
+			}
+			backups = append(backups, backup)
+		case err != nil && !os.IsNotExist(err):
+			return fmt.Errorf("read %s: %w", clean, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(path, snap.Files[rel]); err != nil {
+			return fmt.Errorf("write %s: %w", clean, err)
+		}
+		files = append(files, clean)
+		written++
+	}
+
+	removed := 0
+	for _, clean := range previous.Files {
+		if _, ok := targets[clean]; ok {
+			continue
+		}
+		if _, ok, err := sourcePath(clean); err != nil || !ok {
+			continue
+		}
+		err := os.Remove(filepath.Join(m.pathDefault, clean))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", clean, err)
+		}
+		removed++
+	}
+
+	sort.Strings(files)
+	if err := m.writeSourceManifest(sourceManifest{
+		Source:   m.source.Name(),
+		Version:  snap.Version,
+		Files:    files,
+		SyncedAt: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("write source manifest: %w", err)
+	}
+	m.sourceVersion = snap.Version
+
+	if len(backups) > 0 {
+		sort.Strings(backups)
+		m.log.WithCtx(ctx).Warn(
+			"config source took over local files on first sync, backups kept",
+			zap.String("source", m.source.Name()),
+			zap.Strings("backups", backups),
+		)
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		m.log.WithCtx(ctx).Warn(
+			"config source files conflict with local files, keeping local copies",
+			zap.String("source", m.source.Name()),
+			zap.Strings("files", conflicts),
+		)
+	}
+	m.log.WithCtx(ctx).Info(
+		"config source synchronized",
+		zap.String("source", m.source.Name()),
+		zap.String("version", snap.Version),
+		zap.Int("written", written),
+		zap.Int("removed", removed),
+	)
+	return nil
+}
+
+func (m *Manager) readSourceManifest() sourceManifest {
+	var manifest sourceManifest
+	data, err := os.ReadFile(filepath.Join(m.pathDefault, FileSourceManifest))
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		m.log.Warn("failed to parse config source manifest", zap.Error(err))
+	}
+	return manifest
+}
+
+func (m *Manager) writeSourceManifest(manifest sourceManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(m.pathDefault, FileSourceManifest), data)
+}
+
+func nextBackupPath(path string) string {
+	for n := 1; ; n++ {
+		backup := fmt.Sprintf("%s.v%d%s", path, n, ExtensionBackup)
+		if _, err := os.Stat(backup); os.IsNotExist(err) {
+			return backup
+		}
+	}
+}
+
+// sourcePath keeps source files inside the defaults directory. Entries that are not config files report ok=false.
+func sourcePath(rel string) (string, bool, error) {
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if filepath.IsAbs(clean) || clean == "." || clean == ".." ||
+		strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", false, fmt.Errorf("%w: %s", ErrSourcePath, rel)
+	}
+	if _, ok := configStem(filepath.Base(clean)); !ok || strings.HasPrefix(filepath.Base(clean), ".") {
+		return clean, false, nil
+	}
+	return clean, true, nil
+}
@@ -0,0 +1,92 @@
+package config_manager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileSource mirrors config files from another directory, e.g. a shared network mount.
+type FileSource struct {
+	dir string
+}
+
+func NewFileSource(dir string) *FileSource {
+	return &FileSource{dir: filepath.Clean(dir)}
+}
+
+func (s *FileSource) Name() string {
+	return "file:" + s.dir
+}
+
+func (s *FileSource) Fetch(
+	ctx context.Context,
+	current string,
+) (*SourceSnapshot, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(
+		s.dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if path != s.dir && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if _, ok := configStem(d.Name()); !ok {
+				return nil
+			}
+
+			content, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(s.dir, path)
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(rel)] = content
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	version := snapshotVersion(files)
+	if version == current {
+		return nil, nil
+	}
+
+	return &SourceSnapshot{Version: version, Files: files}, nil
+}
+
+func snapshotVersion(files map[string][]byte) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(files[name])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
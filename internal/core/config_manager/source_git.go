@@ -0,0 +1,100 @@
+package config_manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// GitSource reads config files from a git commit; use a remote-tracking ref such as origin/main to follow upstream.
+type GitSource struct {
+	dir  string
+	ref  string
+	path string
+}
+
+func NewGitSource(dir, ref, subPath string) *GitSource {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return &GitSource{
+		dir:  dir,
+		ref:  ref,
+		path: strings.Trim(path.Clean("/"+subPath), "/"),
+	}
+}
+
+func (s *GitSource) Name() string {
+	return fmt.Sprintf("git:%s@%s", s.dir, s.ref)
+}
+
+func (s *GitSource) Fetch(
+	ctx context.Context,
+	current string,
+) (*SourceSnapshot, error) {
+	remotes, err := s.git(ctx, "remote")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(remotes)) > 0 {
+		if _, err := s.git(ctx, "fetch", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := s.git(ctx, "rev-parse", "--verify", s.ref+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	commit := strings.TrimSpace(string(out))
+	if commit == current {
+		return nil, nil
+	}
+
+	args := []string{"ls-tree", "-r", "-z", "--name-only", commit}
+	if s.path != "" {
+		args = append(args, "--", s.path)
+	}
+	out, err = s.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		if _, ok := configStem(path.Base(name)); !ok {
+			continue
+		}
+		content, err := s.git(ctx, "show", commit+":"+name)
+		if err != nil {
+			return nil, err
+		}
+		rel := name
+		if s.path != "" {
+			rel = strings.TrimPrefix(name, s.path+"/")
+		}
+		files[rel] = content
+	}
+
+	return &SourceSnapshot{Version: commit, Files: files}, nil
+}
+
+func (s *GitSource) git(
+	ctx context.Context,
+	args ...string,
+) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", s.dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package config_manager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPSource polls a URL serving {"files": {"<path>": "<content>"}} and relies on ETag/If-None-Match to skip unchanged bundles.
+type HTTPSource struct {
+	url    string
+	client *http.Client
+}
+
+type httpBundle struct {
+	Version string            `json:"version"`
+	Files   map[string]string `json:"files"`
+}
+
+func NewHTTPSource(
+	url string,
+	client *http.Client,
+) *HTTPSource {
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	return &HTTPSource{url: url, client: client}
+}
+
+func (s *HTTPSource) Name() string {
+	return s.url
+}
+
+// Fetch sends the current version as If-None-Match; it is the ETag whenever the server sends one.
+func (s *HTTPSource) Fetch(
+	ctx context.Context,
+	current string,
+) (*SourceSnapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if current != "" {
+		req.Header.Set("If-None-Match", current)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxSourceBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > MaxSourceBodySize {
+		return nil, fmt.Errorf("response exceeds %d bytes", MaxSourceBodySize)
+	}
+
+	var bundle httpBundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		return nil, fmt.Errorf("parse bundle: %w", err)
+	}
+
+	version := resp.Header.Get("ETag")
+	if version == "" {
+		version = bundle.Version
+	}
+	if version == "" {
+		sum := sha256.Sum256(body)
+		version = hex.EncodeToString(sum[:])
+	}
+
+	if version == current {
+		return nil, nil
+	}
+
+	files := make(map[string][]byte, len(bundle.Files))
+	for name, content := range bundle.Files {
+		files[name] = []byte(content)
+	}
+	return &SourceSnapshot{Version: version, Files: files}, nil
+}
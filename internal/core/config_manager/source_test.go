@@ -0,0 +1,357 @@
+package config_manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"DiscordBotAgent/internal/core/zap_logger"
+)
+
+func setupSourceManager(
+	t *testing.T,
+	source ConfigSource,
+) (*Manager, string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	defaultPath := filepath.Join(tmpDir, "config_df")
+
+	m, err := New(zap_logger.NewNop(), defaultPath, filepath.Join(tmpDir, "config_mrg"), WithSource(source, time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(func() { _ = m.Close() })
+	return m, defaultPath
+}
+
+func TestFileSource_MirrorsAndReloads(t *testing.T) {
+	remote := t.TempDir()
+	writeYamlFile(t, filepath.Join(remote, "shared.yaml"), "name: remote\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(remote, "extra.yaml"), "name: extra\n")
+	writeYamlFile(t, filepath.Join(remote, "README.md"), "not a config")
+
+	m, defaultPath := setupSourceManager(t, NewFileSource(remote))
+
+	if err := m.Register("shared", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(defaultPath, "README.md")); !os.IsNotExist(err) {
+		t.Error("non-config files must not be mirrored")
+	}
+
+	writeYamlFile(t, filepath.Join(remote, "shared.yaml"), "name: remote\ncount: 7\n")
+	if err := os.Remove(filepath.Join(remote, "extra.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+	m.reloadConfig(context.Background(), "shared")
+
+	if cfg := m.Get("shared").(TestConfig); cfg.Count != 7 {
+		t.Errorf("expected count 7 after sync, got %d", cfg.Count)
+	}
+	if _, err := os.Stat(filepath.Join(defaultPath, "extra.yaml")); !os.IsNotExist(err) {
+		t.Error("files removed from the source must be removed locally")
+	}
+	if _, err := os.Stat(filepath.Join(defaultPath, DirSchemas)); err != nil {
+		t.Error("files not owned by the source must be kept")
+	}
+}
+
+func TestSource_InvalidConfigKeepsLastGood(t *testing.T) {
+	remote := t.TempDir()
+	writeYamlFile(t, filepath.Join(remote, "guarded.yaml"), "name: remote\ncount: 1\n")
+
+	m, _ := setupSourceManager(t, NewFileSource(remote))
+	if err := m.Register("guarded", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	writeYamlFile(t, filepath.Join(remote, "guarded.yaml"), "name: remote\ncount: 500\n")
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+	m.reloadConfig(context.Background(), "guarded")
+
+	if cfg := m.Get("guarded").(TestConfig); cfg.Count != 1 {
+		t.Errorf("expected last good count 1, got %d", cfg.Count)
+	}
+	if info, _ := m.Info("guarded"); info.Rejected == nil {
+		t.Error("expected rejected revision to be recorded")
+	}
+}
+
+func TestHTTPSource_UsesETag(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", `"v1"`)
+				_ = json.NewEncoder(w).Encode(
+					httpBundle{Files: map[string]string{"remote.yaml": "name: http\ncount: 3\n"}},
+				)
+			},
+		),
+	)
+	defer server.Close()
+
+	source := NewHTTPSource(server.URL, server.Client())
+	m, _ := setupSourceManager(t, source)
+
+	if err := m.Register("remote", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if cfg := m.Get("remote").(TestConfig); cfg.Name != "http" || cfg.Count != 3 {
+		t.Errorf("unexpected config from HTTP source: %+v", cfg)
+	}
+
+	snap, err := source.Fetch(context.Background(), m.sourceVersion)
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap != nil || requests != 2 {
+		t.Errorf("expected a 304 to yield no snapshot, got %+v after %d requests", snap, requests)
+	}
+}
+
+func TestHTTPSource_RejectsEscapingPaths(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(
+					httpBundle{Files: map[string]string{"../escape.yaml": "name: x\n"}},
+				)
+			},
+		),
+	)
+	defer server.Close()
+
+	m, _, _ := setupTestManager(t)
+	defer m.Close()
+	m.source = NewHTTPSource(server.URL, server.Client())
+
+	if err := m.syncSource(context.Background()); !errors.Is(err, ErrSourcePath) {
+		t.Errorf("expected ErrSourcePath, got %v", err)
+	}
+}
+
+func TestHTTPSource_SkipsNonConfigEntries(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(
+					httpBundle{Files: map[string]string{"README.md": "docs", "bundled.yaml": "name: http\n"}},
+				)
+			},
+		),
+	)
+	defer server.Close()
+
+	m, defaultPath := setupSourceManager(t, NewHTTPSource(server.URL, server.Client()))
+
+	if _, err := os.Stat(filepath.Join(defaultPath, "bundled.yaml")); err != nil {
+		t.Errorf("expected config entry to be mirrored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(defaultPath, "README.md")); !os.IsNotExist(err) {
+		t.Error("non-config entries must be skipped")
+	}
+	if m.readSourceManifest().Version == "" {
+		t.Error("expected the sync to complete")
+	}
+}
+
+func TestSource_FailedSyncIsRetried(t *testing.T) {
+	remote := t.TempDir()
+	writeYamlFile(t, filepath.Join(remote, "retried.yaml"), "name: remote\n")
+
+	tmpDir := t.TempDir()
+	defaultPath := filepath.Join(tmpDir, "config_df")
+	blocked := filepath.Join(defaultPath, "retried.yaml")
+	if err := os.MkdirAll(blocked, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := New(zap_logger.NewNop(), defaultPath, filepath.Join(tmpDir, "config_mrg"), WithSource(NewFileSource(remote), time.Hour))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer m.Close()
+
+	if m.sourceVersion != "" {
+		t.Fatal("a failed sync must not record the source version")
+	}
+
+	if err := os.Remove(blocked); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+	if content, err := os.ReadFile(blocked); err != nil || string(content) != "name: remote\n" {
+		t.Errorf("expected the retry to write the file, got %q, %v", content, err)
+	}
+}
+
+func TestSource_FirstSyncTakesOverLocalFiles(t *testing.T) {
+	remote := t.TempDir()
+	writeYamlFile(t, filepath.Join(remote, "local.yaml"), "name: remote\n")
+	writeYamlFile(t, filepath.Join(remote, "same.yaml"), "name: same\n")
+
+	tmpDir := t.TempDir()
+	defaultPath := filepath.Join(tmpDir, "config_df")
+	writeYamlFile(t, filepath.Join(defaultPath, "local.yaml"), "name: operator\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "same.yaml"), "name: same\n")
+
+	m, err := New(zap_logger.NewNop(), defaultPath, filepath.Join(tmpDir, "config_mrg"), WithSource(NewFileSource(remote), time.Hour))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer m.Close()
+
+	if content, _ := os.ReadFile(filepath.Join(defaultPath, "local.yaml")); string(content) != "name: remote\n" {
+		t.Errorf("first sync must take over the local file, got %q", content)
+	}
+	backup, err := os.ReadFile(filepath.Join(defaultPath, "local.yaml.v1"+ExtensionBackup))
+	if err != nil || string(backup) != "name: operator\n" {
+		t.Errorf("expected a backup of the local file, got %q, %v", backup, err)
+	}
+
+	writeYamlFile(t, filepath.Join(remote, "local.yaml"), "name: updated\n")
+	writeYamlFile(t, filepath.Join(remote, "late.yaml"), "name: remote\n")
+	writeYamlFile(t, filepath.Join(defaultPath, "late.yaml"), "name: operator\n")
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(defaultPath, "local.yaml")); string(content) != "name: updated\n" {
+		t.Errorf("taken over file must follow the source, got %q", content)
+	}
+	if content, _ := os.ReadFile(filepath.Join(defaultPath, "late.yaml")); string(content) != "name: operator\n" {
+		t.Errorf("unowned file must not be overwritten after the first sync, got %q", content)
+	}
+}
+
+func TestSource_OverwritesPlaceholderFromFailedFirstSync(t *testing.T) {
+	remote := filepath.Join(t.TempDir(), "remote")
+
+	m, defaultPath := setupSourceManager(t, NewFileSource(remote))
+	if m.sourceVersion != "" {
+		t.Fatal("sync from a missing directory must fail")
+	}
+	_ = m.Register("pending", TestConfig{}, nil)
+	path := filepath.Join(defaultPath, "pending.yaml")
+	if content, err := os.ReadFile(path); err != nil || !isPlaceholder(content) {
+		t.Fatalf("expected a placeholder, got %q, %v", content, err)
+	}
+
+	writeYamlFile(t, filepath.Join(remote, "pending.yaml"), "name: remote\n")
+	writeYamlFile(t, filepath.Join(remote, "other.yaml"), "name: other\n")
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+	if err := m.createPlaceholder("late", filepath.Join(defaultPath, "late.yaml"), TestConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	writeYamlFile(t, filepath.Join(remote, "late.yaml"), "name: late\n")
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+
+	for file, want := range map[string]string{"pending.yaml": "name: remote\n", "late.yaml": "name: late\n"} {
+		if content, _ := os.ReadFile(filepath.Join(defaultPath, file)); string(content) != want {
+			t.Errorf("placeholder %s must be replaced by the source, got %q", file, content)
+		}
+	}
+}
+
+func TestGitSource_ReadsCommittedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	git("init", "--quiet")
+	writeYamlFile(t, filepath.Join(repo, "configs", "versioned.yaml"), "name: git\ncount: 1\n")
+	writeYamlFile(t, filepath.Join(repo, "other.yaml"), "name: outside\n")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "initial")
+
+	writeYamlFile(t, filepath.Join(repo, "configs", "versioned.yaml"), "name: git\ncount: 9\n")
+
+	source := NewGitSource(repo, "", "configs")
+	m, defaultPath := setupSourceManager(t, source)
+
+	if err := m.Register("versioned", TestConfig{}, nil); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if cfg := m.Get("versioned").(TestConfig); cfg.Count != 1 {
+		t.Errorf("expected committed count 1, got %d", cfg.Count)
+	}
+	if _, err := os.Stat(filepath.Join(defaultPath, "other.yaml")); !os.IsNotExist(err) {
+		t.Error("files outside the source path must not be mirrored")
+	}
+
+	if snap, err := source.Fetch(context.Background(), m.sourceVersion); err != nil || snap != nil {
+		t.Errorf("expected no snapshot for the same commit, got %+v, %v", snap, err)
+	}
+
+	git("commit", "--quiet", "-am", "bump")
+	if err := m.syncSource(context.Background()); err != nil {
+		t.Fatalf("syncSource() error: %v", err)
+	}
+	m.reloadConfig(context.Background(), "versioned")
+
+	if cfg := m.Get("versioned").(TestConfig); cfg.Count != 9 {
+		t.Errorf("expected count 9 after new commit, got %d", cfg.Count)
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"https://example.com/configs", "https://example.com/configs"},
+		{"file:/mnt/configs", "file:/mnt/configs"},
+		{"git:/srv/configs?ref=origin/main&path=config_df", "git:/srv/configs@origin/main"},
+	}
+	for _, tt := range tests {
+		source, err := ParseSource(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSource(%q) error: %v", tt.spec, err)
+		}
+		if source.Name() != tt.want {
+			t.Errorf("ParseSource(%q).Name() = %q, want %q", tt.spec, source.Name(), tt.want)
+		}
+	}
+
+	if source, err := ParseSource(""); source != nil || err != nil {
+		t.Errorf("expected no source for empty spec, got %v, %v", source, err)
+	}
+	if _, err := ParseSource("ftp://example.com"); !errors.Is(err, ErrSourceSpec) {
+		t.Errorf("expected ErrSourceSpec, got %v", err)
+	}
+}
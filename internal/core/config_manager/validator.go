@@ -1,7 +1,11 @@
 package config_manager
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 
 	"DiscordBotAgent/internal/core/zap_logger"
 
@@ -9,14 +13,76 @@ import (
 	"go.uber.org/zap"
 )
 
+type FieldError struct {
+	Path    string `json:"path"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func (fe FieldError) String() string {
+	var b strings.Builder
+	if fe.File != "" {
+		b.WriteString(fe.File)
+		if fe.Line > 0 {
+			fmt.Fprintf(&b, ":%d:%d", fe.Line, fe.Column)
+		}
+		b.WriteString(": ")
+	}
+	if fe.Path != "" {
+		b.WriteString(fe.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(fe.Message)
+	return b.String()
+}
+
+type ValidationError struct {
+	Config string
+	Fields []FieldError
+	err    error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("validation failed: %v", e.err)
+	}
+
+	msgs := make([]string, 0, len(e.Fields))
+	for _, fe := range e.Fields {
+		msgs = append(msgs, fe.String())
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
 type ConfigValidator struct {
+	mu       sync.RWMutex
 	validate *validator.Validate
 	log      *zap_logger.Logger
 }
 
 func NewValidator(log *zap_logger.Logger) *ConfigValidator {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(
+		func(field reflect.StructField) string {
+			key := yamlKeyOf(field)
+			if key == "-" {
+				return ""
+			}
+			return key
+		},
+	)
+	registerBuiltinRules(validate)
+
 	return &ConfigValidator{
-		validate: validator.New(),
+		validate: validate,
 		log:      log,
 	}
 }
@@ -27,16 +93,86 @@ func (v *ConfigValidator) Validate(
 ) error {
 	v.log.Debug("starting configuration validation", zap.String("config", name))
 
+	v.mu.RLock()
 	err := v.validate.Struct(cfg)
+	v.mu.RUnlock()
 	if err != nil {
+		vErr := newValidationError(name, err)
 		v.log.Error(
 			"configuration validation failed",
 			zap.String("config", name),
-			zap.Error(err),
+			zap.Any("errors", vErr.Fields),
 		)
-		return fmt.Errorf("validation failed: %w", err)
+		return vErr
 	}
 
 	v.log.Debug("configuration validation passed", zap.String("config", name))
 	return nil
 }
+
+func newValidationError(
+	name string,
+	err error,
+) *ValidationError {
+	result := &ValidationError{Config: name, err: err}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		result.Fields = []FieldError{{Message: err.Error()}}
+		return result
+	}
+
+	for _, fe := range fieldErrs {
+		result.Fields = append(
+			result.Fields, FieldError{
+				Path:    yamlPathOf(fe.Namespace()),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: fieldMessage(fe),
+			},
+		)
+	}
+	return result
+}
+
+func yamlPathOf(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+	return path
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "value is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", strings.Join(strings.Fields(fe.Param()), ", "))
+	case "gte", "min":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "lte", "max":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "lt":
+		return fmt.Sprintf("must be less than %s", fe.Param())
+	case TagSnowflake:
+		return "must be a Discord snowflake ID (17-20 digits)"
+	case TagHexColor:
+		return "must be a hex color like #ff0000 or 0xff0000"
+	case TagDuration:
+		return "must be a duration like 30s, 5m or 1h30m"
+	case TagRegexp:
+		return "must be a valid regular expression"
+	case TagEmoji:
+		return "must be a unicode emoji or a custom emoji like <:name:id>"
+	case TagChannelType:
+		return fmt.Sprintf("must be a channel type (%s)", strings.Join(ChannelTypes, ", "))
+	default:
+		if fe.Param() != "" {
+			return fmt.Sprintf("failed '%s=%s' rule", fe.Tag(), fe.Param())
+		}
+		return fmt.Sprintf("failed '%s' rule", fe.Tag())
+	}
+}
@@ -1,6 +1,7 @@
 package config_manager
 
 import (
+	"errors"
 	"testing"
 
 	"DiscordBotAgent/internal/core/zap_logger"
@@ -75,3 +76,34 @@ func TestValidate_NegativeValue(t *testing.T) {
 		t.Error("expected validation error for negative value")
 	}
 }
+
+type YamlPathConfig struct {
+	LogDetails YamlPathInner `yaml:"logDetails" validate:"required"`
+}
+
+type YamlPathInner struct {
+	Channel string `yaml:"channel_id" validate:"required"`
+}
+
+func TestValidate_ReportsYamlPaths(t *testing.T) {
+	logger, _ := zap_logger.New()
+	v := NewValidator(logger)
+
+	err := v.Validate("testconfig", YamlPathConfig{})
+
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(vErr.Fields) != 1 {
+		t.Fatalf("expected 1 field error, got %d", len(vErr.Fields))
+	}
+
+	fe := vErr.Fields[0]
+	if fe.Path != "logDetails.channel_id" {
+		t.Errorf("expected path 'logDetails.channel_id', got '%s'", fe.Path)
+	}
+	if fe.Rule != "required" {
+		t.Errorf("expected rule 'required', got '%s'", fe.Rule)
+	}
+}
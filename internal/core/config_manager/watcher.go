@@ -3,9 +3,13 @@ package config_manager
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"DiscordBotAgent/pkg/ctxtrace"
 	"github.com/fsnotify/fsnotify"
@@ -27,6 +31,9 @@ func (m *Manager) initWatcher() error {
 	if err := m.watcher.Add(m.pathMerge); err != nil {
 		return err
 	}
+	if err := m.watchGuildDirs(); err != nil {
+		return err
+	}
 
 	m.log.Debug("file system watcher initialized for config directories")
 	return nil
@@ -39,9 +46,7 @@ func (m *Manager) watchLoop() {
 			if !ok {
 				return
 			}
-			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) {
-				m.handleFileSystemEvent(event.Name, event.Op.String())
-			}
+			m.dispatchEvent(event)
 		case err, ok := <-m.watcher.Errors:
 			if !ok {
 				return
@@ -51,26 +56,130 @@ func (m *Manager) watchLoop() {
 	}
 }
 
+func (m *Manager) dispatchEvent(event fsnotify.Event) {
+	path := filepath.Clean(event.Name)
+
+	switch {
+	case m.isWatchedRoot(path) && (event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)):
+		m.log.Warn("config directory removed, waiting for it to reappear", zap.String("path", path))
+		m.rewatchDir(path)
+	case filepath.Base(path) == KubeDataDir && event.Has(fsnotify.Create):
+		m.scheduleReloadAll(event.Op.String())
+	case event.Has(fsnotify.Write) || event.Has(fsnotify.Create) ||
+		event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		m.handleFileSystemEvent(path, event.Op.String())
+	}
+}
+
+func (m *Manager) isWatchedRoot(path string) bool {
+	return path == filepath.Clean(m.pathDefault) ||
+		path == filepath.Clean(m.pathMerge) ||
+		path == m.guildsDir()
+}
+
+func (m *Manager) isConfigDir(dir string) bool {
+	return m.isWatchedRoot(dir) || dir == m.guildsDir() || filepath.Dir(dir) == m.guildsDir()
+}
+
+func (m *Manager) rewatchDir(path string) {
+	m.mu.Lock()
+	if m.rewatching[path] {
+		m.mu.Unlock()
+		return
+	}
+	m.rewatching[path] = true
+	m.mu.Unlock()
+
+	_ = m.watcher.Remove(path)
+
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			delete(m.rewatching, path)
+			m.mu.Unlock()
+		}()
+
+		ticker := time.NewTicker(DirRecheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+			}
+
+			if info, err := os.Stat(path); err != nil || !info.IsDir() {
+				continue
+			}
+
+			err := m.watcher.Add(path)
+			if err == nil && path != filepath.Clean(m.pathDefault) {
+				err = m.watchGuildDirs()
+			}
+			if err != nil {
+				m.log.Error("failed to re-add config directory watch", zap.String("path", path), zap.Error(err))
+				continue
+			}
+
+			m.log.Info("config directory watch restored", zap.String("path", path))
+			m.scheduleReloadAll(OpRecreate)
+			return
+		}
+	}()
+}
+
 func (m *Manager) handleFileSystemEvent(
 	path string,
 	op string,
 ) {
+	dir := filepath.Clean(filepath.Dir(path))
+	if m.handleIncludeEvent(path, op) && !m.isConfigDir(dir) {
+		return
+	}
+
+	if dir == m.guildsDir() {
+		m.handleGuildDirEvent(path, op)
+		return
+	}
+
 	fileName := filepath.Base(path)
-	if !strings.HasSuffix(fileName, ExtensionYaml) {
+	stem, ok := configStem(fileName)
+	if !ok {
+		return
+	}
+
+	configName := strings.TrimPrefix(stem, PrefixMerge)
+
+	if filepath.Dir(dir) == m.guildsDir() {
+		ctx := ctxtrace.WithCorrelationID(context.Background(), m.generateTraceID())
+		m.handleGuildEvent(ctx, configName, filepath.Base(dir))
 		return
 	}
 
-	configName := strings.TrimSuffix(fileName, ExtensionYaml)
-	configName = strings.TrimPrefix(configName, PrefixMerge)
+	if !m.isRegistered(configName) {
+		return
+	}
+
+	m.scheduleReload(configName, op, fileName)
+}
 
+func (m *Manager) scheduleReloadAll(op string) {
 	m.mu.RLock()
-	_, registered := m.registry[configName]
+	names := make([]string, 0, len(m.registry))
+	for name := range m.registry {
+		names = append(names, name)
+	}
 	m.mu.RUnlock()
 
-	if !registered {
-		return
+	for _, name := range names {
+		m.scheduleReload(name, op, "")
 	}
+}
 
+func (m *Manager) scheduleReload(
+	configName, op, fileName string,
+) {
 	traceID := m.generateTraceID()
 	ctx := ctxtrace.WithCorrelationID(context.Background(), traceID)
 
@@ -81,6 +190,18 @@ func (m *Manager) handleFileSystemEvent(
 		zap.String("file", fileName),
 	)
 
+	// Explicit file events reload even if the files hash the same, e.g. to pick up a rotated secret.
+	if op != OpPoll && op != OpRecreate {
+		m.mu.Lock()
+		m.forcedReloads[configName] = true
+		m.mu.Unlock()
+	}
+
+	if m.batchWindow > 0 {
+		m.scheduleBatch(ctx, configName)
+		return
+	}
+
 	m.mu.Lock()
 	if timer, ok := m.timers[configName]; ok {
 		timer.Stop()
@@ -100,54 +221,249 @@ func (m *Manager) handleFileSystemEvent(
 	)
 }
 
+func (m *Manager) pollLoop() {
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+
+	m.log.Info("config polling enabled", zap.Duration("interval", m.pollInterval))
+
+	scheduled := make(map[string]string)
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.RLock()
+		hashes := make(map[string]string, len(m.registry))
+		for name, meta := range m.registry {
+			hashes[name] = meta.sourceHash
+		}
+		m.mu.RUnlock()
+
+		for name, known := range hashes {
+			if _, err := os.Stat(m.basePath(name)); err != nil {
+				continue
+			}
+			hash := m.sourceHash(name)
+			if hash == known {
+				delete(scheduled, name)
+				continue
+			}
+			if hash == scheduled[name] {
+				continue
+			}
+			scheduled[name] = hash
+			m.scheduleReload(name, OpPoll, "")
+		}
+	}
+}
+
+func (m *Manager) sourceHash(name string) string {
+	h := sha256.New()
+	_, baseFiles := m.locateFile(m.pathDefault, name)
+	_, mergeFiles := m.locateFile(m.pathMerge, PrefixMerge+name)
+	paths := append(append(baseFiles, mergeFiles...), m.includesOf(name)...)
+	for _, path := range paths {
+		content, _ := os.ReadFile(path)
+		h.Write([]byte(path))
+		h.Write([]byte{0})
+		h.Write(content)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (m *Manager) reloadConfig(
 	ctx context.Context,
 	name string,
 ) {
+	ctx, source := m.takeOrigin(ctx, name)
+
+	meta, res, stage, err := m.stageReload(ctx, name)
+	if err != nil {
+		m.rejectReload(ctx, meta, source, stage, err)
+		return
+	}
+	if res == nil {
+		return
+	}
+
+	_ = m.applyConfig(ctx, meta, res, source)
+}
+
+// stageReload loads a changed config without applying it; a nil result means there is nothing to apply.
+func (m *Manager) stageReload(
+	ctx context.Context,
+	name string,
+) (*ConfigMeta, *loadResult, string, error) {
 	m.mu.Lock()
 	meta, ok := m.registry[name]
 	m.mu.Unlock()
 
 	if !ok {
-		return
+		return nil, nil, "", nil
 	}
 
-	m.log.WithCtx(ctx).Info("hot-reloading configuration", zap.String("config", name))
-
-	newCfg, err := m.loadAndMerge(name, meta.StructType)
-	if err != nil {
-		m.log.WithCtx(ctx).Error(
-			"hot-reload failed: load error. module will be disabled",
+	if _, err := os.Stat(m.basePath(name)); err != nil {
+		m.log.WithCtx(ctx).Warn(
+			"base configuration file missing, keeping current configuration",
 			zap.String("config", name),
 			zap.Error(err),
 		)
-		if meta.OnUpdate != nil {
-			go meta.OnUpdate(nil, false)
-		}
+		return meta, nil, "", nil
+	}
+
+	hash := m.sourceHash(name)
+	m.mu.Lock()
+	unchanged := !m.forcedReloads[name] && meta.sourceHash != "" && meta.sourceHash == hash
+	delete(m.forcedReloads, name)
+	meta.sourceHash = hash
+	m.mu.Unlock()
+
+	if unchanged {
+		m.log.WithCtx(ctx).Debug("configuration content unchanged, reload skipped", zap.String("config", name))
+		return meta, nil, "", nil
+	}
+
+	m.log.WithCtx(ctx).Info("hot-reloading configuration", zap.String("config", name))
+
+	res, err := m.load(name, meta.StructType)
+	if err != nil {
+		return meta, nil, "load", err
+	}
+	return meta, res, "", nil
+}
+
+func (m *Manager) applyConfig(
+	ctx context.Context,
+	meta *ConfigMeta,
+	res *loadResult,
+	source string,
+) error {
+	if err := m.validate(meta.Name, res); err != nil {
+		m.rejectReload(ctx, meta, source, "validation", err)
+		return err
+	}
+
+	m.mu.Lock()
+	previous := m.swapConfig(meta, res)
+	m.mu.Unlock()
+
+	event := m.finishApply(ctx, meta, res, previous, source)
+	m.log.WithCtx(ctx).Debug("triggering update callback (valid)", zap.String("config", meta.Name))
+	go meta.notify(ctx, event)
+	return nil
+}
+
+// swapConfig must be called with m.mu held; it returns the replaced state for diffing.
+func (m *Manager) swapConfig(
+	meta *ConfigMeta,
+	res *loadResult,
+) appliedState {
+	previous := appliedState{value: meta.CurrentValue, secrets: meta.secrets}
+	meta.CurrentValue = res.Value
+	meta.EnvOverrides = res.EnvOverrides
+	meta.secrets = res.secrets
+	meta.Rejected = nil
+	m.invalidateGuilds(meta, "")
+	return previous
+}
+
+func (m *Manager) finishApply(
+	ctx context.Context,
+	meta *ConfigMeta,
+	res *loadResult,
+	previous appliedState,
+	source string,
+) UpdateEvent {
+	m.persistMigrations(ctx, meta.Name, res)
+	m.recordRevision(ctx, meta.Name, res, source)
+
+	changes, err := diffValues(previous.value, res.Value)
+	if err != nil {
+		m.log.WithCtx(ctx).Warn("failed to compute configuration diff", zap.String("config", meta.Name), zap.Error(err))
+	}
+	changes = redactChanges(previous.secrets, res.secrets, changes)
+	m.logChanges(ctx, meta.Name, changes)
+	m.recordAudit(
+		ctx, AuditEntry{
+			Config:  meta.Name,
+			Source:  source,
+			Outcome: AuditApplied,
+			Changes: changes,
+		},
+	)
+
+	m.log.WithCtx(ctx).Info(
+		"configuration successfully applied",
+		zap.String("config", meta.Name),
+		zap.String("source", source),
+	)
+	return UpdateEvent{Config: res.Value, Valid: true, Changes: changes}
+}
+
+func (m *Manager) logChanges(
+	ctx context.Context,
+	name string,
+	changes []Change,
+) {
+	if len(changes) == 0 {
+		m.log.WithCtx(ctx).Info("configuration reloaded without effective changes", zap.String("config", name))
 		return
 	}
 
-	if err := m.validator.Validate(name, newCfg); err != nil {
-		m.log.WithCtx(ctx).Error(
-			"hot-reload failed: validation error. module will be disabled",
+	for _, c := range changes {
+		m.log.WithCtx(ctx).Info(
+			"configuration key changed",
 			zap.String("config", name),
+			zap.String("path", c.Path),
+			zap.String("change", string(c.Type)),
+			zap.Any("old", c.Old),
+			zap.Any("new", c.New),
 		)
-		if meta.OnUpdate != nil {
-			go meta.OnUpdate(nil, false)
-		}
-		return
 	}
+}
+
+func (m *Manager) rejectReload(
+	ctx context.Context,
+	meta *ConfigMeta,
+	source, stage string,
+	err error,
+) {
+	m.recordRejection(ctx, meta.Name, source, stage, err)
 
 	m.mu.Lock()
-	meta.CurrentValue = newCfg
+	keepLastGood := meta.Policy == PolicyKeepLastGood && meta.CurrentValue != nil
+	meta.Rejected = &RejectedRevision{
+		TraceID:    ctxtrace.Extract(ctx),
+		Error:      err.Error(),
+		Errors:     errorFields(err),
+		RejectedAt: time.Now(),
+	}
 	m.mu.Unlock()
 
-	if meta.OnUpdate != nil {
-		m.log.WithCtx(ctx).Debug("triggering update callback (valid)", zap.String("config", name))
-		go meta.OnUpdate(newCfg, true)
+	if keepLastGood {
+		m.log.WithCtx(ctx).Error(
+			"hot-reload rejected: keeping last known good configuration",
+			zap.String("config", meta.Name),
+			zap.String("stage", stage),
+			zap.Error(err),
+		)
+		go meta.notify(ctx, UpdateEvent{KeptLastGood: true, Errors: errorFields(err)})
+		return
 	}
 
-	m.log.WithCtx(ctx).Info("configuration successfully reloaded", zap.String("config", name))
+	m.log.WithCtx(ctx).Error(
+		"hot-reload failed: module will be disabled",
+		zap.String("config", meta.Name),
+		zap.String("stage", stage),
+		zap.String("policy", string(meta.Policy)),
+		zap.Error(err),
+	)
+	go meta.notify(ctx, UpdateEvent{Valid: false, Errors: errorFields(err)})
 }
 
 func (m *Manager) generateTraceID() string {
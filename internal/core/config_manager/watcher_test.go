@@ -0,0 +1,211 @@
+package config_manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func registerWithUpdates(
+	t *testing.T,
+	m *Manager,
+	name string,
+) chan TestConfig {
+	t.Helper()
+
+	updates := make(chan TestConfig, 4)
+	err := m.Register(
+		name,
+		TestConfig{},
+		func(
+			cfg any,
+			isValid bool,
+		) {
+			if isValid {
+				updates <- cfg.(TestConfig)
+			}
+		},
+	)
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	<-updates
+	return updates
+}
+
+func TestReload_SkipsUnchangedContent(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	writeYamlFile(t, filepath.Join(defaultPath, "unchanged.yaml"), "name: same\ncount: 1")
+	updates := registerWithUpdates(t, m, "unchanged")
+
+	m.reloadConfig(context.Background(), "unchanged")
+
+	select {
+	case <-updates:
+		t.Error("reload must be skipped when content did not change")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReload_MissingBaseKeepsCurrentConfig(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "vanished.yaml")
+	writeYamlFile(t, path, "name: here\ncount: 1")
+	updates := registerWithUpdates(t, m, "vanished")
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	m.reloadConfig(context.Background(), "vanished")
+
+	select {
+	case <-updates:
+		t.Error("callback must not be called while the base file is missing")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	info, _ := m.Info("vanished")
+	if !info.Active || info.Rejected != nil {
+		t.Errorf("expected active config without rejection, got %+v", info)
+	}
+}
+
+func TestWatcher_AtomicRenameTriggersReload(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "atomic.yaml")
+	writeYamlFile(t, path, "name: before\ncount: 1")
+	updates := registerWithUpdates(t, m, "atomic")
+
+	tmp := filepath.Join(defaultPath, ".atomic.yaml.swp")
+	writeYamlFile(t, tmp, "name: after\ncount: 2")
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+
+	select {
+	case cfg := <-updates:
+		if cfg.Name != "after" {
+			t.Errorf("expected reloaded name 'after', got %q", cfg.Name)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("reload was not triggered by atomic rename")
+	}
+}
+
+func TestWatcher_RecreatedDirectoryIsWatchedAgain(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	path := filepath.Join(defaultPath, "recreated.yaml")
+	writeYamlFile(t, path, "name: before\ncount: 1")
+	updates := registerWithUpdates(t, m, "recreated")
+
+	if err := os.RemoveAll(defaultPath); err != nil {
+		t.Fatalf("remove dir: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	writeYamlFile(t, path, "name: after\ncount: 2")
+
+	select {
+	case cfg := <-updates:
+		if cfg.Name != "after" {
+			t.Errorf("expected reloaded name 'after', got %q", cfg.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload was not triggered after directory recreation")
+	}
+}
+
+func TestPolling_DetectsContentChange(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+
+	m.pollInterval = 20 * time.Millisecond
+	go m.pollLoop()
+
+	path := filepath.Join(defaultPath, "polled.yaml")
+	writeYamlFile(t, path, "name: before\ncount: 1")
+	updates := registerWithUpdates(t, m, "polled")
+
+	m.mu.Lock()
+	m.registry["polled"].sourceHash = "stale"
+	m.mu.Unlock()
+
+	select {
+	case cfg := <-updates:
+		if cfg.Name != "before" {
+			t.Errorf("unexpected config after poll: %+v", cfg)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("poller did not schedule a reload for changed content")
+	}
+}
+
+func TestReload_DetectsFormatConflictInEitherDir(t *testing.T) {
+	cases := map[string]func(defaultPath, mergePath string) string{
+		"base":  func(defaultPath, _ string) string { return filepath.Join(defaultPath, "conflict.json") },
+		"merge": func(_, mergePath string) string { return filepath.Join(mergePath, "MERGE.conflict.toml") },
+	}
+
+	for name, conflictPath := range cases {
+		t.Run(
+			name, func(t *testing.T) {
+				m, defaultPath, mergePath := setupTestManager(t)
+				defer m.Close()
+
+				writeYamlFile(t, filepath.Join(defaultPath, "conflict.yaml"), "name: base\ncount: 1")
+				writeYamlFile(t, filepath.Join(mergePath, "MERGE.conflict.yaml"), "count: 2")
+				registerWithUpdates(t, m, "conflict")
+
+				writeYamlFile(t, conflictPath(defaultPath, mergePath), "")
+				m.reloadConfig(context.Background(), "conflict")
+
+				info, _ := m.Info("conflict")
+				if info.Rejected == nil {
+					t.Fatal("expected the format conflict to reject the reload")
+				}
+			},
+		)
+	}
+}
+
+func TestReload_ExplicitEventPicksUpRotatedSecret(t *testing.T) {
+	m, defaultPath, _ := setupTestManager(t)
+	defer m.Close()
+	m.secrets = fakeSecrets{"NAME": "old"}
+
+	writeYamlFile(t, filepath.Join(defaultPath, "rotated.yaml"), "name: ${secret:NAME}\ncount: 1")
+	updates := registerWithUpdates(t, m, "rotated")
+	time.Sleep(DebounceDuration + 100*time.Millisecond)
+	for len(updates) > 0 {
+		<-updates
+	}
+
+	m.secrets = fakeSecrets{"NAME": "new"}
+	m.scheduleReload("rotated", OpPoll, "")
+
+	select {
+	case <-updates:
+		t.Fatal("a poll with unchanged files must not reload")
+	case <-time.After(DebounceDuration + 100*time.Millisecond):
+	}
+
+	m.scheduleReload("rotated", "WRITE", "rotated.yaml")
+
+	select {
+	case cfg := <-updates:
+		if cfg.Name != "new" {
+			t.Errorf("expected rotated secret, got %q", cfg.Name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("explicit file event did not reload unchanged files")
+	}
+}
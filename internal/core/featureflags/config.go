@@ -0,0 +1,50 @@
+package featureflags
+
+type Config struct {
+	Flags map[string]Flag `yaml:"flags" json:"flags" validate:"dive" desc:"Feature flags by name, e.g. template2.process_messages."`
+}
+
+type Flag struct {
+	Enabled    bool    `yaml:"enabled" json:"enabled" desc:"Master switch; a disabled flag is off for everyone, including allow-listed targets."`
+	Percentage *int    `yaml:"percentage,omitempty" json:"percentage,omitempty" validate:"omitempty,gte=0,lte=100" desc:"Share of guilds or users that get the flag, chosen by a stable hash." example:"25"`
+	RolloutBy  string  `yaml:"rollout_by,omitempty" json:"rollout_by,omitempty" validate:"omitempty,oneof=guild user" desc:"Identity hashed for the percentage rollout (default guild)." example:"user"`
+	Allow      Targets `yaml:"allow,omitempty" json:"allow,omitempty" desc:"Targets that always get the flag. Without a percentage, only these targets get it."`
+	Deny       Targets `yaml:"deny,omitempty" json:"deny,omitempty" desc:"Targets that never get the flag; deny wins over allow."`
+}
+
+type Targets struct {
+	Guilds []string `yaml:"guilds,omitempty" json:"guilds,omitempty" validate:"dive,numeric" desc:"Guild IDs."`
+	Users  []string `yaml:"users,omitempty" json:"users,omitempty" validate:"dive,numeric" desc:"User IDs."`
+	Roles  []string `yaml:"roles,omitempty" json:"roles,omitempty" validate:"dive,numeric" desc:"Role IDs."`
+}
+
+func (t Targets) empty() bool {
+	return len(t.Guilds) == 0 && len(t.Users) == 0 && len(t.Roles) == 0
+}
+
+func (t Targets) match(s Subject) bool {
+	if contains(t.Guilds, s.GuildID) || contains(t.Users, s.UserID) {
+		return true
+	}
+	for _, role := range s.RoleIDs {
+		if contains(t.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(
+	list []string,
+	value string,
+) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package featureflags
+
+import "time"
+
+type Reason string
+
+const (
+	ReasonUnknown         Reason = "unknown"
+	ReasonDisabled        Reason = "disabled"
+	ReasonDenied          Reason = "denied"
+	ReasonAllowed         Reason = "allowed"
+	ReasonNotAllowed      Reason = "not_allowed"
+	ReasonRollout         Reason = "rollout"
+	ReasonRolloutExcluded Reason = "rollout_excluded"
+	ReasonEnabled         Reason = "enabled"
+)
+
+const (
+	RolloutByGuild = "guild"
+	RolloutByUser  = "user"
+
+	RecentEvaluations = 20
+)
+
+type Subject struct {
+	GuildID string   `json:"guild_id,omitempty"`
+	UserID  string   `json:"user_id,omitempty"`
+	RoleIDs []string `json:"role_ids,omitempty"`
+}
+
+type Evaluation struct {
+	Flag        string    `json:"flag"`
+	Enabled     bool      `json:"enabled"`
+	Reason      Reason    `json:"reason"`
+	Subject     Subject   `json:"subject"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+}
+
+type FlagStatus struct {
+	Name       string       `json:"name"`
+	Defined    bool         `json:"defined"`
+	Flag       *Flag        `json:"flag,omitempty"`
+	TrueCount  int64        `json:"true_count"`
+	FalseCount int64        `json:"false_count"`
+	Recent     []Evaluation `json:"recent"`
+}
+
+type flagStats struct {
+	trueCount  int64
+	falseCount int64
+	recent     []Evaluation
+}
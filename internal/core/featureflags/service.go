@@ -0,0 +1,218 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/zap_logger"
+
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	mu    sync.RWMutex
+	log   *zap_logger.Logger
+	flags map[string]Flag
+	stats map[string]*flagStats
+}
+
+// New registers the hot-reloaded flag config; a missing or invalid file starts with every flag off.
+// The service is always returned; an error reports the config problem to the caller.
+func New(
+	log *zap_logger.Logger,
+	cm *config_manager.Manager,
+) (*Service, error) {
+	s := &Service{
+		log:   log,
+		flags: make(map[string]Flag),
+		stats: make(map[string]*flagStats),
+	}
+
+	err := cm.Register(
+		config_manager.Contract.System.FeatureFlags,
+		Config{Flags: map[string]Flag{}},
+		nil,
+		config_manager.WithChangeCallback(s.onConfigUpdate),
+		config_manager.WithRegisterOnError(),
+	)
+	if errors.Is(err, config_manager.ErrConfigMissing) {
+		return s, err
+	}
+	if errors.Is(err, config_manager.ErrPlaceholderCreated) {
+		log.Warn(
+			"feature flag configuration was missing, all flags are off",
+			zap.String("config_file", config_manager.Contract.System.FeatureFlags+config_manager.ExtensionYaml),
+		)
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("feature flags config: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Service) onConfigUpdate(
+	ctx context.Context,
+	event config_manager.UpdateEvent,
+) {
+	if event.KeptLastGood {
+		s.log.WithCtx(ctx).Warn("feature flag update rejected, keeping current flags")
+		return
+	}
+
+	flags := make(map[string]Flag)
+	if event.Valid {
+		flags = event.Config.(Config).Flags
+	}
+
+	s.mu.Lock()
+	s.flags = flags
+	s.mu.Unlock()
+
+	s.log.WithCtx(ctx).Info("feature flags updated", zap.Int("flags", len(flags)), zap.Bool("valid", event.Valid))
+}
+
+// Enabled evaluates a flag for a guild member and records the result.
+func (s *Service) Enabled(
+	ctx context.Context,
+	name string,
+	guildID, userID string,
+	roleIDs ...string,
+) bool {
+	return s.Evaluate(ctx, name, Subject{GuildID: guildID, UserID: userID, RoleIDs: roleIDs}).Enabled
+}
+
+func (s *Service) Evaluate(
+	ctx context.Context,
+	name string,
+	subject Subject,
+) Evaluation {
+	e := s.Check(name, subject)
+
+	s.mu.Lock()
+	st, ok := s.stats[name]
+	if !ok {
+		st = &flagStats{}
+		s.stats[name] = st
+	}
+	if e.Enabled {
+		st.trueCount++
+	} else {
+		st.falseCount++
+	}
+	st.recent = append(st.recent, e)
+	if len(st.recent) > RecentEvaluations {
+		st.recent = st.recent[1:]
+	}
+	s.mu.Unlock()
+
+	s.log.WithCtx(ctx).Debug(
+		"feature flag evaluated",
+		zap.String("flag", name),
+		zap.Bool("enabled", e.Enabled),
+		zap.String("reason", string(e.Reason)),
+		zap.String("guild_id", subject.GuildID),
+		zap.String("user_id", subject.UserID),
+	)
+	return e
+}
+
+// Check evaluates a flag without recording it.
+func (s *Service) Check(
+	name string,
+	subject Subject,
+) Evaluation {
+	s.mu.RLock()
+	flag, ok := s.flags[name]
+	s.mu.RUnlock()
+
+	enabled, reason := evaluate(name, flag, ok, subject)
+	return Evaluation{
+		Flag:        name,
+		Enabled:     enabled,
+		Reason:      reason,
+		Subject:     subject,
+		EvaluatedAt: time.Now(),
+	}
+}
+
+func evaluate(
+	name string,
+	flag Flag,
+	defined bool,
+	subject Subject,
+) (bool, Reason) {
+	switch {
+	case !defined:
+		return false, ReasonUnknown
+	case !flag.Enabled:
+		return false, ReasonDisabled
+	case flag.Deny.match(subject):
+		return false, ReasonDenied
+	case flag.Allow.match(subject):
+		return true, ReasonAllowed
+	case flag.Percentage != nil:
+		id := subject.GuildID
+		if flag.RolloutBy == RolloutByUser {
+			id = subject.UserID
+		}
+		if id != "" && bucket(name, id) < *flag.Percentage {
+			return true, ReasonRollout
+		}
+		return false, ReasonRolloutExcluded
+	case !flag.Allow.empty():
+		return false, ReasonNotAllowed
+	}
+	return true, ReasonEnabled
+}
+
+// bucket maps an identity to 0-99, stable per flag so rollouts of different flags are independent.
+func bucket(
+	name, id string,
+) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + ":" + id))
+	return int(h.Sum32() % 100)
+}
+
+// Statuses lists defined flags and flags that were evaluated without a definition.
+func (s *Service) Statuses() []FlagStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make(map[string]bool, len(s.flags)+len(s.stats))
+	for name := range s.flags {
+		names[name] = true
+	}
+	for name := range s.stats {
+		names[name] = true
+	}
+
+	result := make([]FlagStatus, 0, len(names))
+	for name := range names {
+		status := FlagStatus{Name: name, Recent: []Evaluation{}}
+		if flag, ok := s.flags[name]; ok {
+			status.Defined = true
+			status.Flag = &flag
+		}
+		if st, ok := s.stats[name]; ok {
+			status.TrueCount = st.trueCount
+			status.FalseCount = st.falseCount
+			status.Recent = append(status.Recent, st.recent...)
+		}
+		result = append(result, status)
+	}
+
+	sort.Slice(
+		result, func(i, j int) bool {
+			return result[i].Name < result[j].Name
+		},
+	)
+	return result
+}
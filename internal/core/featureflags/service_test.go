@@ -0,0 +1,249 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/zap_logger"
+)
+
+func percent(n int) *int {
+	return &n
+}
+
+func TestEvaluate_Rules(t *testing.T) {
+	subject := Subject{GuildID: "100", UserID: "200", RoleIDs: []string{"300"}}
+
+	cases := map[string]struct {
+		flag    Flag
+		defined bool
+		enabled bool
+		reason  Reason
+	}{
+		"unknown flag": {
+			flag:   Flag{Enabled: true},
+			reason: ReasonUnknown,
+		},
+		"disabled": {
+			flag:    Flag{Allow: Targets{Guilds: []string{"100"}}},
+			defined: true,
+			reason:  ReasonDisabled,
+		},
+		"enabled for everyone": {
+			flag:    Flag{Enabled: true},
+			defined: true,
+			enabled: true,
+			reason:  ReasonEnabled,
+		},
+		"deny wins over allow": {
+			flag: Flag{
+				Enabled: true,
+				Allow:   Targets{Guilds: []string{"100"}},
+				Deny:    Targets{Roles: []string{"300"}},
+			},
+			defined: true,
+			reason:  ReasonDenied,
+		},
+		"allow list match": {
+			flag:    Flag{Enabled: true, Allow: Targets{Users: []string{"200"}}},
+			defined: true,
+			enabled: true,
+			reason:  ReasonAllowed,
+		},
+		"allow list only": {
+			flag:    Flag{Enabled: true, Allow: Targets{Users: []string{"999"}}},
+			defined: true,
+			reason:  ReasonNotAllowed,
+		},
+		"allow list beats zero rollout": {
+			flag:    Flag{Enabled: true, Percentage: percent(0), Allow: Targets{Roles: []string{"300"}}},
+			defined: true,
+			enabled: true,
+			reason:  ReasonAllowed,
+		},
+		"full rollout": {
+			flag:    Flag{Enabled: true, Percentage: percent(100)},
+			defined: true,
+			enabled: true,
+			reason:  ReasonRollout,
+		},
+		"zero rollout": {
+			flag:    Flag{Enabled: true, Percentage: percent(0)},
+			defined: true,
+			reason:  ReasonRolloutExcluded,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(
+			name, func(t *testing.T) {
+				enabled, reason := evaluate("flag", tc.flag, tc.defined, subject)
+				if enabled != tc.enabled || reason != tc.reason {
+					t.Errorf("got (%v, %s), want (%v, %s)", enabled, reason, tc.enabled, tc.reason)
+				}
+			},
+		)
+	}
+}
+
+func TestEvaluate_RolloutIsStable(t *testing.T) {
+	flag := Flag{Enabled: true, Percentage: percent(30)}
+
+	in := 0
+	for i := 0; i < 1000; i++ {
+		subject := Subject{GuildID: fmt.Sprint(1000 + i)}
+		first, _ := evaluate("rollout", flag, true, subject)
+		again, _ := evaluate("rollout", flag, true, subject)
+		if first != again {
+			t.Fatalf("guild %s flipped between evaluations", subject.GuildID)
+		}
+		if first != (bucket("rollout", subject.GuildID) < 30) {
+			t.Fatalf("guild %s does not follow its bucket", subject.GuildID)
+		}
+		if first {
+			in++
+		}
+	}
+	if in < 250 || in > 350 {
+		t.Errorf("expected about 30%% of guilds in the rollout, got %d of 1000", in)
+	}
+
+	if bucket("rollout", "1000") != bucket("rollout", "1000") {
+		t.Error("bucket must be deterministic")
+	}
+}
+
+func TestEvaluate_RolloutBy(t *testing.T) {
+	// Find a guild inside and a user outside a 50% rollout so the hashed identity decides the result.
+	var guildIn, userOut string
+	for i := 0; guildIn == "" || userOut == ""; i++ {
+		id := fmt.Sprint(5000 + i)
+		if guildIn == "" && bucket("split", id) < 50 {
+			guildIn = id
+		}
+		if userOut == "" && bucket("split", id) >= 50 {
+			userOut = id
+		}
+	}
+	subject := Subject{GuildID: guildIn, UserID: userOut}
+
+	byGuild, _ := evaluate("split", Flag{Enabled: true, Percentage: percent(50)}, true, subject)
+	if !byGuild {
+		t.Error("expected the default rollout to hash the guild")
+	}
+
+	byUser, reason := evaluate(
+		"split",
+		Flag{Enabled: true, Percentage: percent(50), RolloutBy: RolloutByUser},
+		true,
+		subject,
+	)
+	if byUser || reason != ReasonRolloutExcluded {
+		t.Errorf("expected the user rollout to exclude the user, got (%v, %s)", byUser, reason)
+	}
+
+	missing, _ := evaluate(
+		"split",
+		Flag{Enabled: true, Percentage: percent(100), RolloutBy: RolloutByUser},
+		true,
+		Subject{GuildID: guildIn},
+	)
+	if missing {
+		t.Error("expected a user rollout to skip subjects without a user")
+	}
+}
+
+func TestService_LoadsFlagsAndRecordsStats(t *testing.T) {
+	dir := t.TempDir()
+	pathDefault := filepath.Join(dir, "config_df")
+	pathMerge := filepath.Join(dir, "config_mrg")
+	if err := os.MkdirAll(pathDefault, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	content := "flags:\n  beta:\n    enabled: true\n    allow:\n      guilds: ['100']\n"
+	file := filepath.Join(pathDefault, config_manager.Contract.System.FeatureFlags+config_manager.ExtensionYaml)
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("write flags: %v", err)
+	}
+
+	cm, err := config_manager.New(zap_logger.NewNop(), pathDefault, pathMerge)
+	if err != nil {
+		t.Fatalf("config_manager.New() error: %v", err)
+	}
+	defer cm.Close()
+
+	s, err := New(zap_logger.NewNop(), cm)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	ctx := context.Background()
+	if !s.Enabled(ctx, "beta", "100", "200") {
+		t.Error("expected beta for the allowed guild")
+	}
+	if s.Enabled(ctx, "beta", "101", "200") {
+		t.Error("expected beta off for other guilds")
+	}
+	if s.Enabled(ctx, "missing", "100", "200") {
+		t.Error("expected an unknown flag to be off")
+	}
+
+	statuses := s.Statuses()
+	if len(statuses) != 2 || statuses[0].Name != "beta" || statuses[1].Name != "missing" {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+	if !statuses[0].Defined || statuses[0].TrueCount != 1 || statuses[0].FalseCount != 1 {
+		t.Errorf("unexpected beta status: %+v", statuses[0])
+	}
+	if statuses[1].Defined || statuses[1].Recent[0].Reason != ReasonUnknown {
+		t.Errorf("unexpected unknown flag status: %+v", statuses[1])
+	}
+}
+
+func TestService_InvalidConfigStartsWithFlagsOff(t *testing.T) {
+	dir := t.TempDir()
+	pathDefault := filepath.Join(dir, "config_df")
+	pathMerge := filepath.Join(dir, "config_mrg")
+	if err := os.MkdirAll(pathDefault, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	file := filepath.Join(pathDefault, config_manager.Contract.System.FeatureFlags+config_manager.ExtensionYaml)
+	if err := os.WriteFile(file, []byte("flags:\n  beta:\n    enabled: true\n    percentage: 250\n"), 0644); err != nil {
+		t.Fatalf("write flags: %v", err)
+	}
+
+	cm, err := config_manager.New(zap_logger.NewNop(), pathDefault, pathMerge)
+	if err != nil {
+		t.Fatalf("config_manager.New() error: %v", err)
+	}
+	defer cm.Close()
+
+	s, err := New(zap_logger.NewNop(), cm)
+	if err == nil {
+		t.Fatal("expected New() to report the invalid config")
+	}
+	if s == nil {
+		t.Fatal("expected a service with every flag off")
+	}
+	if e := s.Check("beta", Subject{GuildID: "100"}); e.Enabled {
+		t.Errorf("expected beta off, got %+v", e)
+	}
+
+	time.Sleep(config_manager.DebounceDuration + 100*time.Millisecond)
+	if err := os.WriteFile(file, []byte("flags:\n  beta:\n    enabled: true\n"), 0644); err != nil {
+		t.Fatalf("write flags: %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for !s.Check("beta", Subject{GuildID: "100"}).Enabled {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the fixed config to be hot-reloaded")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -2,6 +2,8 @@ package module_manager
 
 import (
 	"context"
+
+	"DiscordBotAgent/internal/core/config_manager"
 )
 
 type Module interface {
@@ -18,3 +20,42 @@ type Module interface {
 		cfg any,
 	)
 }
+
+type ReloadPolicyProvider interface {
+	ReloadPolicy() config_manager.ReloadPolicy
+}
+
+type ConfigChangeHandler interface {
+	OnConfigChanged(
+		ctx context.Context,
+		cfg any,
+		changes []config_manager.Change,
+	)
+}
+
+type ConfigValidationProvider interface {
+	RegisterValidations(r config_manager.ValidationRegistry) error
+}
+
+type ConfigMigrationProvider interface {
+	ConfigSchemaVersion() int
+	ConfigMigrations() map[int]config_manager.MigrationFunc
+}
+
+// DependencyProvider declares the modules a module needs. When implemented, the legacy reflection scan is skipped.
+type DependencyProvider interface {
+	DependsOn() []Dependency
+}
+
+type VersionProvider interface {
+	Version() string
+}
+
+// OptionalDependencyHandler is notified while the module is enabled when an optional dependency is enabled or disabled.
+type OptionalDependencyHandler interface {
+	OnDependencyChanged(
+		ctx context.Context,
+		name string,
+		available bool,
+	)
+}
@@ -0,0 +1,152 @@
+package module_manager
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+func (m *Manager) moduleDependencies(mod Module) ([]Dependency, error) {
+	p, ok := mod.(DependencyProvider)
+	if !ok {
+		if !m.reflectionScan {
+			return nil, nil
+		}
+		var deps []Dependency
+		for _, name := range scanDependencies(mod) {
+			deps = append(deps, Dependency{Name: name})
+		}
+		if len(deps) > 0 {
+			m.log.Warn(
+				"module dependencies discovered by reflection, implement DependsOn instead",
+				zap.String("module", mod.Name()),
+				zap.Strings("dependencies", dependencyNames(deps, false)),
+			)
+		}
+		return deps, nil
+	}
+
+	deps := p.DependsOn()
+	seen := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		switch {
+		case dep.Name == "" || dep.Name == mod.Name():
+			return nil, fmt.Errorf("invalid dependency %q", dep.Name)
+		case seen[dep.Name]:
+			return nil, fmt.Errorf("duplicate dependency %s", dep.Name)
+		}
+		seen[dep.Name] = true
+		if err := validConstraint(dep.Version); err != nil {
+			return nil, fmt.Errorf("dependency %s: %w", dep.Name, err)
+		}
+	}
+	return deps, nil
+}
+
+func moduleVersion(mod Module) string {
+	if p, ok := mod.(VersionProvider); ok {
+		return p.Version()
+	}
+	return ""
+}
+
+func dependencyNames(
+	deps []Dependency,
+	optional bool,
+) []string {
+	var names []string
+	for _, dep := range deps {
+		if dep.Optional == optional {
+			names = append(names, dep.Name)
+		}
+	}
+	return names
+}
+
+// checkDependency returns a reason when the dependency cannot be used: not registered, wrong version or not enabled.
+func (m *Manager) checkDependency(dep Dependency) (string, ModuleStatus) {
+	m.mu.RLock()
+	depState, exists := m.modules[dep.Name]
+	m.mu.RUnlock()
+
+	if !exists {
+		return fmt.Sprintf("dependency %s not registered", dep.Name), StatusError
+	}
+
+	current := moduleVersion(depState.module)
+	ok, err := satisfies(current, dep.Version)
+	if err != nil || !ok {
+		return fmt.Sprintf("dependency %s version %q does not satisfy %q", dep.Name, current, dep.Version), StatusError
+	}
+
+	if !depState.isEnabled() {
+		return "dependency disabled: " + dep.Name, StatusDepDisabled
+	}
+	return "", StatusEnabled
+}
+
+// DependencyAvailable reports whether a declared dependency of a module is registered, enabled and of a matching version.
+func (m *Manager) DependencyAvailable(
+	moduleName, depName string,
+) bool {
+	m.mu.RLock()
+	state, exists := m.modules[moduleName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return false
+	}
+	for _, dep := range state.dependencies {
+		if dep.Name == depName {
+			reason, _ := m.checkDependency(dep)
+			return reason == ""
+		}
+	}
+	return false
+}
+
+func (m *Manager) notifyOptionalDependents(
+	moduleName string,
+	available bool,
+) {
+	m.mu.RLock()
+	names := m.optionalDependents[moduleName]
+	depState, depExists := m.modules[moduleName]
+	m.mu.RUnlock()
+
+	if !depExists {
+		return
+	}
+	current := moduleVersion(depState.module)
+	ctx := context.Background()
+
+	for _, name := range names {
+		m.mu.RLock()
+		state, exists := m.modules[name]
+		m.mu.RUnlock()
+
+		if !exists || !state.isEnabled() {
+			continue
+		}
+		h, ok := state.module.(OptionalDependencyHandler)
+		if !ok {
+			continue
+		}
+		for _, dep := range state.dependencies {
+			if dep.Name != moduleName {
+				continue
+			}
+			if ok, _ := satisfies(current, dep.Version); !ok {
+				break
+			}
+			h.OnDependencyChanged(ctx, moduleName, available)
+			m.log.Info(
+				"optional dependency changed",
+				zap.String("module", name),
+				zap.String("dependency", moduleName),
+				zap.Bool("available", available),
+			)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package module_manager
 
+import "DiscordBotAgent/internal/core/config_manager"
+
 func GetTypedConfig[T any](
 	m *Manager,
 	moduleName string,
@@ -15,3 +17,45 @@ func GetTypedConfig[T any](
 	}
 	return typed, true
 }
+
+func GetTypedGuildConfig[T any](
+	m *Manager,
+	moduleName, guildID string,
+) (T, bool) {
+	var zero T
+	cfg, ok := m.GetGuildConfig(moduleName, guildID)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := cfg.(T)
+	if !ok {
+		return zero, false
+	}
+	return typed, true
+}
+
+func configOptions(mod Module) []config_manager.Option {
+	var opts []config_manager.Option
+	if p, ok := mod.(ReloadPolicyProvider); ok {
+		opts = append(opts, config_manager.WithReloadPolicy(p.ReloadPolicy()))
+	}
+	if p, ok := mod.(ConfigMigrationProvider); ok {
+		opts = append(opts, config_manager.WithMigrations(p.ConfigSchemaVersion(), p.ConfigMigrations()))
+	}
+	return opts
+}
+
+func (m *Manager) dependencyConfigKeys(deps []Dependency) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var keys []string
+	for _, dep := range deps {
+		if state, ok := m.modules[dep.Name]; ok {
+			if key := state.module.ConfigKey(); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
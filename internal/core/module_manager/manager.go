@@ -13,23 +13,31 @@ import (
 )
 
 type Manager struct {
-	log        *zap_logger.Logger
-	cm         *config_manager.Manager
-	modules    map[string]*moduleState
-	dependents map[string][]string
-	mu         sync.RWMutex
+	log                *zap_logger.Logger
+	cm                 *config_manager.Manager
+	modules            map[string]*moduleState
+	dependents         map[string][]string
+	optionalDependents map[string][]string
+	reflectionScan     bool
+	mu                 sync.RWMutex
 }
 
 func New(
 	log *zap_logger.Logger,
 	cm *config_manager.Manager,
+	opts ...ManagerOption,
 ) *Manager {
-	return &Manager{
-		log:        log,
-		cm:         cm,
-		modules:    make(map[string]*moduleState),
-		dependents: make(map[string][]string),
+	m := &Manager{
+		log:                log,
+		cm:                 cm,
+		modules:            make(map[string]*moduleState),
+		dependents:         make(map[string][]string),
+		optionalDependents: make(map[string][]string),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *Manager) Register(mod Module) error {
@@ -43,31 +51,65 @@ func (m *Manager) Register(mod Module) error {
 		return fmt.Errorf("module %s already registered", name)
 	}
 
-	deps := scanDependencies(mod)
+	deps, depErr := m.moduleDependencies(mod)
 	state := newModuleState(mod, deps)
 
 	m.mu.Lock()
 	m.modules[name] = state
 	for _, dep := range deps {
-		m.dependents[dep] = append(m.dependents[dep], name)
+		if dep.Optional {
+			m.optionalDependents[dep.Name] = append(m.optionalDependents[dep.Name], name)
+		} else {
+			m.dependents[dep.Name] = append(m.dependents[dep.Name], name)
+		}
 	}
 	m.mu.Unlock()
 
+	if depErr != nil {
+		state.setError(fmt.Sprintf("invalid dependencies: %v", depErr))
+		return fmt.Errorf("module %s dependencies: %w", name, depErr)
+	}
+
 	if configKey := mod.ConfigKey(); configKey != "" {
+		if !config_manager.InContract(configKey) {
+			state.setError(fmt.Sprintf("config key %s is not in the config contract", configKey))
+			return fmt.Errorf("module %s: %w: %s", name, config_manager.ErrNotInContract, configKey)
+		}
+
+		if p, ok := mod.(ConfigValidationProvider); ok {
+			if err := p.RegisterValidations(m.cm.Validations()); err != nil {
+				state.setError(fmt.Sprintf("validation rules registration failed: %v", err))
+				return fmt.Errorf("module %s validation rules: %w", name, err)
+			}
+		}
+
 		template := mod.ConfigTemplate()
 
-		err := m.cm.Register(
-			configKey,
-			template,
-			func(
-				cfg any,
-				isValid bool,
-			) {
-				m.onConfigUpdate(name, cfg, isValid)
-			},
+		opts := append(
+			configOptions(mod),
+			config_manager.WithDependsOn(m.dependencyConfigKeys(deps)...),
+			config_manager.WithChangeCallback(
+				func(
+					ctx context.Context,
+					event config_manager.UpdateEvent,
+				) {
+					m.onConfigUpdate(ctx, name, event)
+				},
+			),
 		)
 
+		err := m.cm.Register(configKey, template, nil, opts...)
+
 		if err != nil {
+			if errors.Is(err, config_manager.ErrConfigMissing) {
+				m.log.Warn(
+					"MODULE DISABLED: configuration file is missing",
+					zap.String("module", name),
+					zap.String("config_file", configKey+config_manager.ExtensionYaml),
+				)
+				state.setDisabled("missing configuration")
+				return nil
+			}
 			if errors.Is(err, config_manager.ErrPlaceholderCreated) {
 				m.log.Warn(
 					"MODULE DISABLED: configuration file was missing",
@@ -80,6 +122,10 @@ func (m *Manager) Register(mod Module) error {
 			}
 
 			state.setError(fmt.Sprintf("config registration failed: %v", err))
+			var vErr *config_manager.ValidationError
+			if errors.As(err, &vErr) {
+				state.setConfigErrors("", vErr.Fields)
+			}
 			return fmt.Errorf("module %s config registration: %w", name, err)
 		}
 	} else {
@@ -90,9 +136,9 @@ func (m *Manager) Register(mod Module) error {
 }
 
 func (m *Manager) onConfigUpdate(
+	ctx context.Context,
 	moduleName string,
-	cfg any,
-	isValid bool,
+	event config_manager.UpdateEvent,
 ) {
 	m.mu.RLock()
 	state, exists := m.modules[moduleName]
@@ -102,14 +148,25 @@ func (m *Manager) onConfigUpdate(
 		return
 	}
 
-	ctx := context.Background()
+	cfg := event.Config
 	wasEnabled := state.isEnabled()
 
-	if !isValid {
+	if event.KeptLastGood {
+		state.setConfigErrors("configuration update rejected, running on last known good", event.Errors)
+		m.log.WithCtx(ctx).Warn(
+			"module config update rejected, keeping last known good",
+			zap.String("module", moduleName),
+			zap.Int("errors", len(event.Errors)),
+		)
+		return
+	}
+
+	if !event.Valid {
 		state.setDisabled("invalid configuration")
+		state.setConfigErrors("", event.Errors)
 		if wasEnabled {
 			state.module.OnDisable(ctx)
-			m.log.Warn(
+			m.log.WithCtx(ctx).Warn(
 				"module disabled due to invalid config",
 				zap.String("module", moduleName),
 			)
@@ -121,8 +178,16 @@ func (m *Manager) onConfigUpdate(
 	state.updateConfig(cfg)
 
 	if wasEnabled {
-		state.module.OnConfigUpdate(ctx, cfg)
-		m.log.Info("module config updated", zap.String("module", moduleName))
+		if h, ok := state.module.(ConfigChangeHandler); ok {
+			h.OnConfigChanged(ctx, cfg, event.Changes)
+		} else {
+			state.module.OnConfigUpdate(ctx, cfg)
+		}
+		m.log.WithCtx(ctx).Info(
+			"module config updated",
+			zap.String("module", moduleName),
+			zap.Int("changes", len(event.Changes)),
+		)
 	} else {
 		m.tryEnable(moduleName, cfg)
 	}
@@ -140,27 +205,28 @@ func (m *Manager) tryEnable(
 		return
 	}
 
-	for _, depName := range state.dependencies {
-		m.mu.RLock()
-		depState, depExists := m.modules[depName]
-		m.mu.RUnlock()
+	for _, dep := range state.dependencies {
+		if dep.Optional {
+			continue
+		}
 
-		if !depExists {
-			state.setError(fmt.Sprintf("dependency %s not registered", depName))
+		reason, status := m.checkDependency(dep)
+		switch status {
+		case StatusError:
+			state.setError(reason)
 			m.log.Error(
-				"module dependency not found",
+				"module dependency unusable",
 				zap.String("module", moduleName),
-				zap.String("dependency", depName),
+				zap.String("dependency", dep.Name),
+				zap.String("reason", reason),
 			)
 			return
-		}
-
-		if !depState.isEnabled() {
-			state.setDepDisabled(depName)
+		case StatusDepDisabled:
+			state.setDepDisabled(dep.Name)
 			m.log.Warn(
 				"module waiting for dependency",
 				zap.String("module", moduleName),
-				zap.String("dependency", depName),
+				zap.String("dependency", dep.Name),
 			)
 			return
 		}
@@ -177,6 +243,7 @@ func (m *Manager) tryEnable(
 	m.log.Info("module enabled", zap.String("module", moduleName))
 
 	m.tryEnableDependents(moduleName)
+	m.notifyOptionalDependents(moduleName, true)
 }
 
 func (m *Manager) tryEnableDependents(moduleName string) {
@@ -196,7 +263,10 @@ func (m *Manager) tryEnableDependents(moduleName string) {
 	}
 }
 
+// disableDependents runs after a module went down: hard dependents are disabled, optional ones notified.
 func (m *Manager) disableDependents(moduleName string) {
+	m.notifyOptionalDependents(moduleName, false)
+
 	m.mu.RLock()
 	deps := m.dependents[moduleName]
 	m.mu.RUnlock()
@@ -280,6 +350,29 @@ func (m *Manager) GetConfig(moduleName string) (any, bool) {
 	return state.getConfig()
 }
 
+func (m *Manager) GetGuildConfig(
+	moduleName, guildID string,
+) (any, bool) {
+	m.mu.RLock()
+	state, exists := m.modules[moduleName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	cfg, valid := state.getConfig()
+	configKey := state.module.ConfigKey()
+	if !valid || configKey == "" {
+		return cfg, valid
+	}
+
+	if guildCfg := m.cm.GetForGuild(configKey, guildID); guildCfg != nil {
+		return guildCfg, true
+	}
+	return cfg, true
+}
+
 func (m *Manager) GetModuleInfo(moduleName string) (ModuleInfo, bool) {
 	m.mu.RLock()
 	state, exists := m.modules[moduleName]
@@ -310,9 +403,15 @@ func (m *Manager) PrintReport() {
 			zap.String("module", info.Name),
 			zap.String("status", string(info.Status)),
 		}
+		if info.Version != "" {
+			fields = append(fields, zap.String("version", info.Version))
+		}
 		if len(info.Dependencies) > 0 {
 			fields = append(fields, zap.Strings("depends_on", info.Dependencies))
 		}
+		if len(info.OptionalDependencies) > 0 {
+			fields = append(fields, zap.Strings("optionally_uses", info.OptionalDependencies))
+		}
 		if len(info.Dependents) > 0 {
 			fields = append(fields, zap.Strings("required_by", info.Dependents))
 		}
@@ -0,0 +1,233 @@
+package module_manager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"DiscordBotAgent/internal/core/config_manager"
+	"DiscordBotAgent/internal/core/zap_logger"
+)
+
+type fakeModule struct {
+	name    string
+	version string
+	deps    []Dependency
+	events  *[]string
+}
+
+func (f *fakeModule) Name() string {
+	return f.name
+}
+
+func (f *fakeModule) Version() string {
+	return f.version
+}
+
+func (f *fakeModule) DependsOn() []Dependency {
+	return f.deps
+}
+
+func (f *fakeModule) ConfigKey() string {
+	return ""
+}
+
+func (f *fakeModule) ConfigTemplate() any {
+	return nil
+}
+
+func (f *fakeModule) OnEnable(
+	ctx context.Context,
+	cfg any,
+) {
+	*f.events = append(*f.events, "enable "+f.name)
+}
+
+func (f *fakeModule) OnDisable(ctx context.Context) {
+	*f.events = append(*f.events, "disable "+f.name)
+}
+
+func (f *fakeModule) OnConfigUpdate(
+	ctx context.Context,
+	cfg any,
+) {
+}
+
+func (f *fakeModule) OnDependencyChanged(
+	ctx context.Context,
+	name string,
+	available bool,
+) {
+	*f.events = append(*f.events, fmt.Sprintf("%s sees %s available=%v", f.name, name, available))
+}
+
+func setupTestManager(t *testing.T) (*Manager, *[]string) {
+	t.Helper()
+
+	cm, err := config_manager.New(zap_logger.NewNop(), t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("config_manager.New() error: %v", err)
+	}
+	t.Cleanup(
+		func() {
+			_ = cm.Close()
+		},
+	)
+
+	return New(zap_logger.NewNop(), cm), &[]string{}
+}
+
+func register(
+	t *testing.T,
+	m *Manager,
+	mod Module,
+) {
+	t.Helper()
+	if err := m.Register(mod); err != nil {
+		t.Fatalf("Register(%s) error: %v", mod.Name(), err)
+	}
+}
+
+func assertStatus(
+	t *testing.T,
+	m *Manager,
+	name string,
+	want ModuleStatus,
+) {
+	t.Helper()
+	info, ok := m.GetModuleInfo(name)
+	if !ok {
+		t.Fatalf("module %s not found", name)
+	}
+	if info.Status != want {
+		t.Errorf("module %s status = %s, want %s (%s)", name, info.Status, want, info.ErrorMessage)
+	}
+}
+
+func assertEvents(
+	t *testing.T,
+	events *[]string,
+	want ...string,
+) {
+	t.Helper()
+	if !reflect.DeepEqual(*events, want) {
+		t.Errorf("events = %q, want %q", *events, want)
+	}
+	*events = (*events)[:0]
+}
+
+func TestManager_HardDependencyOrdering(t *testing.T) {
+	m, events := setupTestManager(t)
+
+	register(t, m, &fakeModule{name: "core", version: "1.2.0", events: events})
+	register(t, m, &fakeModule{name: "feature", deps: []Dependency{{Name: "core", Version: "^1.0"}}, events: events})
+	register(t, m, &fakeModule{name: "addon", deps: []Dependency{{Name: "feature"}}, events: events})
+	assertEvents(t, events, "enable core", "enable feature", "enable addon")
+
+	if err := m.Disable("core"); err != nil {
+		t.Fatalf("Disable() error: %v", err)
+	}
+	assertEvents(t, events, "disable core", "disable feature", "disable addon")
+	assertStatus(t, m, "core", StatusDisabled)
+	assertStatus(t, m, "feature", StatusDepDisabled)
+	assertStatus(t, m, "addon", StatusDepDisabled)
+
+	if err := m.Enable("core"); err != nil {
+		t.Fatalf("Enable() error: %v", err)
+	}
+	assertEvents(t, events, "enable core", "enable feature", "enable addon")
+	assertStatus(t, m, "addon", StatusEnabled)
+}
+
+func TestManager_HardDependencyUnusable(t *testing.T) {
+	m, events := setupTestManager(t)
+
+	register(t, m, &fakeModule{name: "core", version: "1.2.0", events: events})
+	register(t, m, &fakeModule{name: "modern", deps: []Dependency{{Name: "core", Version: ">=2.0.0"}}, events: events})
+	register(t, m, &fakeModule{name: "orphan", deps: []Dependency{{Name: "absent"}}, events: events})
+
+	assertEvents(t, events, "enable core")
+	assertStatus(t, m, "modern", StatusError)
+	assertStatus(t, m, "orphan", StatusError)
+	if m.DependencyAvailable("modern", "core") {
+		t.Error("expected a version mismatch to make the dependency unavailable")
+	}
+}
+
+func TestManager_OptionalDependency(t *testing.T) {
+	m, events := setupTestManager(t)
+
+	register(t, m, &fakeModule{name: "consumer", deps: []Dependency{{Name: "extra", Optional: true}}, events: events})
+	assertEvents(t, events, "enable consumer")
+	if m.DependencyAvailable("consumer", "extra") {
+		t.Error("expected an unregistered optional dependency to be unavailable")
+	}
+
+	register(t, m, &fakeModule{name: "extra", events: events})
+	assertEvents(t, events, "enable extra", "consumer sees extra available=true")
+	if !m.DependencyAvailable("consumer", "extra") {
+		t.Error("expected the optional dependency to be available")
+	}
+
+	if err := m.Disable("extra"); err != nil {
+		t.Fatalf("Disable() error: %v", err)
+	}
+	assertEvents(t, events, "disable extra", "consumer sees extra available=false")
+	assertStatus(t, m, "consumer", StatusEnabled)
+
+	if err := m.Disable("consumer"); err != nil {
+		t.Fatalf("Disable() error: %v", err)
+	}
+	if err := m.Enable("extra"); err != nil {
+		t.Fatalf("Enable() error: %v", err)
+	}
+	assertEvents(t, events, "disable consumer", "enable extra")
+}
+
+func TestManager_OptionalDependencyVersionMismatchNotNotified(t *testing.T) {
+	m, events := setupTestManager(t)
+
+	register(
+		t,
+		m,
+		&fakeModule{name: "consumer", deps: []Dependency{{Name: "extra", Version: "^2.0", Optional: true}}, events: events},
+	)
+	register(t, m, &fakeModule{name: "extra", version: "1.0.0", events: events})
+
+	assertEvents(t, events, "enable consumer", "enable extra")
+	if m.DependencyAvailable("consumer", "extra") {
+		t.Error("expected a mismatched optional dependency to be unavailable")
+	}
+}
+
+func TestManager_RegisterRejectsInvalidDependsOn(t *testing.T) {
+	cases := map[string][]Dependency{
+		"self":                {{Name: "broken"}},
+		"empty name":          {{Name: ""}},
+		"duplicate":           {{Name: "core"}, {Name: "core", Optional: true}},
+		"unknown operator":    {{Name: "core", Version: "=>1.0.0"}},
+		"malformed version":   {{Name: "core", Version: "^1.x"}},
+		"trailing constraint": {{Name: "core", Version: ">=1.0.0,"}},
+	}
+
+	for name, deps := range cases {
+		t.Run(
+			name, func(t *testing.T) {
+				m, events := setupTestManager(t)
+
+				err := m.Register(&fakeModule{name: "broken", deps: deps, events: events})
+				if err == nil {
+					t.Fatal("expected Register() to fail")
+				}
+				assertStatus(t, m, "broken", StatusError)
+				if len(*events) != 0 {
+					t.Errorf("expected the module to stay disabled, got %q", *events)
+				}
+				if err := m.Register(&fakeModule{name: "broken", events: events}); err == nil {
+					t.Error("expected a second registration under the same name to fail")
+				}
+			},
+		)
+	}
+}
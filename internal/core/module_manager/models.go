@@ -1,6 +1,10 @@
 package module_manager
 
-import "time"
+import (
+	"time"
+
+	"DiscordBotAgent/internal/core/config_manager"
+)
 
 type ModuleStatus string
 
@@ -11,13 +15,24 @@ const (
 	StatusDepDisabled ModuleStatus = "dependency_disabled"
 )
 
+// Dependency names another module. A hard dependency must be enabled before the module is enabled;
+// an optional one is used when present. Version is a constraint such as ">=1.2.0, <2.0.0" or "^1.2".
+type Dependency struct {
+	Name     string
+	Version  string
+	Optional bool
+}
+
 type ModuleInfo struct {
-	Name         string
-	Status       ModuleStatus
-	ConfigKey    string
-	ConfigValid  bool
-	Dependencies []string
-	Dependents   []string
-	ErrorMessage string
-	LastUpdated  time.Time
+	Name                 string
+	Version              string
+	Status               ModuleStatus
+	ConfigKey            string
+	ConfigValid          bool
+	Dependencies         []string
+	OptionalDependencies []string
+	Dependents           []string
+	ErrorMessage         string
+	ConfigErrors         []config_manager.FieldError
+	LastUpdated          time.Time
 }
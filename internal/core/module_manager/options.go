@@ -0,0 +1,11 @@
+package module_manager
+
+type ManagerOption func(m *Manager)
+
+// WithReflectionScan turns on the deprecated dependency discovery that walks module fields,
+// including unexported ones, for other modules. It only applies to modules without DependsOn.
+func WithReflectionScan() ManagerOption {
+	return func(m *Manager) {
+		m.reflectionScan = true
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"unsafe"
 )
 
+// scanDependencies finds modules stored in the fields of mod. Deprecated: it turns any module held in a field
+// into a hard dependency and relies on unsafe access to unexported fields; modules should implement DependsOn.
+// It only runs when the manager is created with WithReflectionScan.
 func scanDependencies(mod Module) []string {
 	seen := make(map[string]bool)
 	var deps []string
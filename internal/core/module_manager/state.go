@@ -3,6 +3,8 @@ package module_manager
 import (
 	"sync"
 	"time"
+
+	"DiscordBotAgent/internal/core/config_manager"
 )
 
 type moduleState struct {
@@ -10,15 +12,16 @@ type moduleState struct {
 	status       ModuleStatus
 	configValid  bool
 	currentCfg   any
-	dependencies []string
+	dependencies []Dependency
 	errorMessage string
+	configErrors []config_manager.FieldError
 	lastUpdated  time.Time
 	mu           sync.RWMutex
 }
 
 func newModuleState(
 	m Module,
-	deps []string,
+	deps []Dependency,
 ) *moduleState {
 	return &moduleState{
 		module:       m,
@@ -36,6 +39,7 @@ func (s *moduleState) setEnabled(cfg any) {
 	s.configValid = true
 	s.currentCfg = cfg
 	s.errorMessage = ""
+	s.configErrors = nil
 	s.lastUpdated = time.Now()
 }
 
@@ -69,6 +73,23 @@ func (s *moduleState) updateConfig(cfg any) {
 	defer s.mu.Unlock()
 	s.currentCfg = cfg
 	s.configValid = true
+	s.configErrors = nil
+	if s.status == StatusEnabled {
+		s.errorMessage = ""
+	}
+	s.lastUpdated = time.Now()
+}
+
+func (s *moduleState) setConfigErrors(
+	message string,
+	errs []config_manager.FieldError,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if message != "" {
+		s.errorMessage = message
+	}
+	s.configErrors = errs
 	s.lastUpdated = time.Now()
 }
 
@@ -82,14 +103,17 @@ func (s *moduleState) getInfo(dependents []string) ModuleInfo {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return ModuleInfo{
-		Name:         s.module.Name(),
-		Status:       s.status,
-		ConfigKey:    s.module.ConfigKey(),
-		ConfigValid:  s.configValid,
-		Dependencies: s.dependencies,
-		Dependents:   dependents,
-		ErrorMessage: s.errorMessage,
-		LastUpdated:  s.lastUpdated,
+		Name:                 s.module.Name(),
+		Version:              moduleVersion(s.module),
+		Status:               s.status,
+		ConfigKey:            s.module.ConfigKey(),
+		ConfigValid:          s.configValid,
+		Dependencies:         dependencyNames(s.dependencies, false),
+		OptionalDependencies: dependencyNames(s.dependencies, true),
+		Dependents:           dependents,
+		ErrorMessage:         s.errorMessage,
+		ConfigErrors:         s.configErrors,
+		LastUpdated:          s.lastUpdated,
 	}
 }
 
@@ -0,0 +1,105 @@
+package module_manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type version [3]int
+
+// parseVersion reads major[.minor[.patch]] with an optional "v" prefix; pre-release and build suffixes are ignored.
+func parseVersion(s string) (version, error) {
+	var v version
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(raw, "-+"); i >= 0 {
+		raw = raw[:i]
+	}
+
+	parts := strings.Split(raw, ".")
+	if raw == "" || len(parts) > 3 {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v version) compare(o version) int {
+	for i := range v {
+		if v[i] != o[i] {
+			if v[i] < o[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// satisfies checks a version against comma-separated constraints: =, !=, >, >=, <, <=,
+// ^ (same major) and ~ (same minor). A bare version means =; an empty constraint matches anything.
+func satisfies(
+	current, constraint string,
+) (bool, error) {
+	if strings.TrimSpace(constraint) == "" {
+		return true, nil
+	}
+	if current == "" {
+		return false, nil
+	}
+
+	have, err := parseVersion(current)
+	if err != nil {
+		return false, err
+	}
+
+	// Every part is parsed even after a mismatch so validConstraint catches malformed later parts.
+	result := true
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		op := strings.TrimRight(part[:len(part)-len(strings.TrimLeft(part, "=!<>^~"))], " ")
+		want, err := parseVersion(part[len(op):])
+		if err != nil {
+			return false, fmt.Errorf("constraint %q: %w", constraint, err)
+		}
+
+		cmp := have.compare(want)
+		ok := false
+		switch op {
+		case "", "=", "==":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "^":
+			ok = cmp >= 0 && have[0] == want[0]
+		case "~":
+			ok = cmp >= 0 && have[0] == want[0] && have[1] == want[1]
+		default:
+			return false, fmt.Errorf("constraint %q: unknown operator %q", constraint, op)
+		}
+		if !ok {
+			result = false
+		}
+	}
+	return result, nil
+}
+
+// validConstraint reports malformed constraints at registration instead of at enable time.
+func validConstraint(constraint string) error {
+	_, err := satisfies("0.0.0", constraint)
+	return err
+}
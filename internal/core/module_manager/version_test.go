@@ -0,0 +1,80 @@
+package module_manager
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    version
+		wantErr bool
+	}{
+		{in: "1.2.3", want: version{1, 2, 3}},
+		{in: "v2.0.1", want: version{2, 0, 1}},
+		{in: "1.4", want: version{1, 4, 0}},
+		{in: "3", want: version{3, 0, 0}},
+		{in: " 1.2.3 ", want: version{1, 2, 3}},
+		{in: "1.2.3-beta.1", want: version{1, 2, 3}},
+		{in: "1.2.3+build.7", want: version{1, 2, 3}},
+		{in: "", wantErr: true},
+		{in: "1.2.3.4", wantErr: true},
+		{in: "1.x", wantErr: true},
+		{in: "1.-2", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := parseVersion(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseVersion(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && got != tc.want {
+			t.Errorf("parseVersion(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	cases := []struct {
+		current    string
+		constraint string
+		want       bool
+		wantErr    bool
+	}{
+		{current: "1.2.3", constraint: "", want: true},
+		{current: "", constraint: ">=1.0.0", want: false},
+		{current: "1.2.3", constraint: "1.2.3", want: true},
+		{current: "1.2.3", constraint: "==1.2.4", want: false},
+		{current: "1.2.3", constraint: "!=1.2.4", want: true},
+		{current: "1.2.3", constraint: ">1.2", want: true},
+		{current: "1.2.3", constraint: "<1.2.3", want: false},
+		{current: "1.2.3", constraint: "<=1.2.3", want: true},
+		{current: "1.5.0", constraint: "^1.2", want: true},
+		{current: "2.0.0", constraint: "^1.2", want: false},
+		{current: "1.1.9", constraint: "^1.2", want: false},
+		{current: "1.2.9", constraint: "~1.2.3", want: true},
+		{current: "1.3.0", constraint: "~1.2.3", want: false},
+		{current: "1.4.0", constraint: ">=1.2.0, <2.0.0", want: true},
+		{current: "2.1.0", constraint: ">=1.2.0, <2.0.0", want: false},
+		{current: "1.0.0", constraint: ">= 1.0.0", want: true},
+		{current: "1.2.3-rc.1", constraint: "1.2.3", want: true},
+		{current: "v1.2.3", constraint: "^v1.0.0", want: true},
+		{current: "1.2.3", constraint: "=>1.0.0", wantErr: true},
+		{current: "1.2.3", constraint: "^", wantErr: true},
+		{current: "1.2.3", constraint: ">=1.0.0,", wantErr: true},
+		{current: "1.2.3", constraint: "<1.0.0, =>2.0.0", wantErr: true},
+		{current: "one", constraint: ">=1.0.0", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := satisfies(tc.current, tc.constraint)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("satisfies(%q, %q) error = %v, wantErr %v", tc.current, tc.constraint, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && got != tc.want {
+			t.Errorf("satisfies(%q, %q) = %v, want %v", tc.current, tc.constraint, got, tc.want)
+		}
+	}
+}
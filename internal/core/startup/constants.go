@@ -11,6 +11,17 @@ const (
 	EnvAppIDKey   = "APP_ID"
 	EnvGuildIDKey = "GUILD_ID"
 	EnvPortKey    = "HTTP_PORT"
+
+	EnvConfigPollKey      = "CONFIG_POLL_INTERVAL"
+	EnvConfigWriteBackKey = "CONFIG_MIGRATION_WRITEBACK"
+	EnvConfigBatchKey     = "CONFIG_BATCH_WINDOW"
+
+	EnvConfigSourceKey         = "CONFIG_SOURCE"
+	EnvConfigSourceIntervalKey = "CONFIG_SOURCE_INTERVAL"
+
+	EnvAPIKeysKey = "API_KEYS"
+
+	EnvModuleReflectionScanKey = "MODULE_REFLECTION_SCAN"
 )
 
 const (
@@ -21,10 +32,16 @@ const (
 	FilePort    = "port.enc"
 )
 
+const (
+	DirSecrets      = "secrets"
+	ExtensionSecret = ".enc"
+)
+
 var EnvFiles = []string{".env.dev", ".env"}
 
 const (
 	TokenRegexPattern = `^[\w-]{24,28}\.[\w-]{6}\.[\w-]{27,45}$`
 	IDRegexPattern    = `^\d{17,20}$`
+	SecretNamePattern = `^[A-Za-z_][A-Za-z0-9_]*$`
 	PortRegexPattern  = `^([1-9][0-9]{0,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])$`
 )
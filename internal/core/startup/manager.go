@@ -15,10 +15,14 @@ type StartupConfig struct {
 	Port    string
 }
 
-func GetStartupConfig() (*StartupConfig, error) {
+func LoadEnvFiles() {
 	for _, file := range EnvFiles {
 		_ = godotenv.Load(file)
 	}
+}
+
+func GetStartupConfig() (*StartupConfig, error) {
+	LoadEnvFiles()
 
 	key, err := deriveKey()
 	if err != nil {
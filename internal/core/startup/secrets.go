@@ -0,0 +1,70 @@
+package startup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type SecretStore struct {
+	key []byte
+	dir string
+}
+
+func NewSecretStore() (*SecretStore, error) {
+	key, err := deriveKey()
+	if err != nil {
+		return nil, err
+	}
+	return &SecretStore{key: key, dir: DirSecrets}, nil
+}
+
+func NewEnvSecretStore() *SecretStore {
+	return &SecretStore{dir: DirSecrets}
+}
+
+func (s *SecretStore) Resolve(name string) (string, bool) {
+	if !ValidateSecretName(name) {
+		return "", false
+	}
+
+	if data, err := os.ReadFile(s.path(name)); err == nil && s.key != nil {
+		if decrypted, err := decrypt(data, s.key); err == nil {
+			return string(decrypted), true
+		}
+	}
+
+	if val, ok := os.LookupEnv(name); ok && val != "" {
+		return val, true
+	}
+	return "", false
+}
+
+func (s *SecretStore) Save(
+	name, value string,
+) error {
+	if !ValidateSecretName(name) {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+	if s.key == nil {
+		return fmt.Errorf("secret store has no encryption key")
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("create secrets dir: %w", err)
+	}
+	return saveEncrypted(s.path(name), value, s.key)
+}
+
+func (s *SecretStore) Delete(name string) error {
+	if !ValidateSecretName(name) {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+	if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *SecretStore) path(name string) string {
+	return filepath.Join(s.dir, name+ExtensionSecret)
+}
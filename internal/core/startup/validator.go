@@ -6,15 +6,17 @@ import (
 )
 
 var (
-	tokenRegex = regexp.MustCompile(TokenRegexPattern)
-	idRegex    = regexp.MustCompile(IDRegexPattern)
-	portRegex  = regexp.MustCompile(PortRegexPattern)
+	tokenRegex  = regexp.MustCompile(TokenRegexPattern)
+	idRegex     = regexp.MustCompile(IDRegexPattern)
+	portRegex   = regexp.MustCompile(PortRegexPattern)
+	secretRegex = regexp.MustCompile(SecretNamePattern)
 )
 
-func ValidateToken(token string) bool { return tokenRegex.MatchString(token) }
-func ValidateID(id string) bool       { return idRegex.MatchString(id) }
-func ValidatePrefix(p string) bool    { return len(p) > 0 && len(p) < 5 }
-func ValidatePort(p string) bool      { return portRegex.MatchString(p) }
+func ValidateToken(token string) bool  { return tokenRegex.MatchString(token) }
+func ValidateID(id string) bool        { return idRegex.MatchString(id) }
+func ValidatePrefix(p string) bool     { return len(p) > 0 && len(p) < 5 }
+func ValidatePort(p string) bool       { return portRegex.MatchString(p) }
+func ValidateSecretName(n string) bool { return secretRegex.MatchString(n) }
 
 func CleanInput(in string) string {
 	in = strings.TrimSpace(in)
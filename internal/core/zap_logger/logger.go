@@ -54,3 +54,7 @@ func (l *Logger) WithCtx(ctx context.Context) *zap.Logger {
 	}
 	return l.With(zap.String("corrid", id))
 }
+
+func NewNop() *Logger {
+	return &Logger{Logger: zap.NewNop()}
+}
@@ -1,13 +1,13 @@
 package template
 
 type Config struct {
-	Enabled    *bool      `yaml:"enabled" validate:"required"`
-	LogDetails LogDetails `yaml:"logDetails" validate:"required"`
+	Enabled    *bool      `yaml:"enabled" validate:"required" desc:"Turns message logging on or off without disabling the module."`
+	LogDetails LogDetails `yaml:"logDetails" validate:"required" desc:"Selects which parts of an incoming message are logged."`
 }
 
 type LogDetails struct {
-	Guild   bool `yaml:"guild"`
-	Channel bool `yaml:"channel"`
-	Author  bool `yaml:"author"`
-	Content bool `yaml:"content"`
+	Guild   bool `yaml:"guild" desc:"Log the guild ID the message was sent in."`
+	Channel bool `yaml:"channel" desc:"Log the channel ID the message was sent in."`
+	Author  bool `yaml:"author" desc:"Log the author's username and user ID."`
+	Content bool `yaml:"content" desc:"Log the full message text."`
 }
@@ -2,14 +2,20 @@ package template
 
 import (
 	"context"
+	"strings"
 
 	"DiscordBotAgent/internal/core/config_manager"
 	"DiscordBotAgent/internal/core/eventbus"
 	"DiscordBotAgent/internal/core/module_manager"
 	"DiscordBotAgent/internal/core/zap_logger"
+
+	"go.uber.org/zap"
 )
 
-const ModuleName = "template"
+const (
+	ModuleName    = "template"
+	ModuleVersion = "1.0.0"
+)
 
 type Module struct {
 	log           *zap_logger.Logger
@@ -39,6 +45,10 @@ func (m *Module) Name() string {
 	return ModuleName
 }
 
+func (m *Module) Version() string {
+	return ModuleVersion
+}
+
 func (m *Module) ConfigKey() string {
 	return config_manager.Contract.System.Discord.Template
 }
@@ -84,6 +94,24 @@ func (m *Module) OnConfigUpdate(
 	m.cfg = cfg.(Config)
 }
 
+func (m *Module) OnConfigChanged(
+	ctx context.Context,
+	cfg any,
+	changes []config_manager.Change,
+) {
+	m.cfg = cfg.(Config)
+
+	for _, c := range changes {
+		if strings.HasPrefix(c.Path, "logDetails.") {
+			m.log.WithCtx(ctx).Info(
+				"template module: log details changed",
+				zap.String("field", c.Path),
+				zap.Any("value", c.New),
+			)
+		}
+	}
+}
+
 func (m *Module) GetConfig() Config {
 	return m.cfg
 }
@@ -1,7 +1,7 @@
 package template2
 
 type Config struct {
-	Prefix  string `yaml:"prefix" validate:"required"`
-	Enabled bool   `yaml:"enabled"`
-	MaxLogs int    `yaml:"max_logs" validate:"gte=0,lte=1000"`
+	Prefix  string `yaml:"prefix" validate:"required" desc:"Prefix reported with every processed message." example:"!"`
+	Enabled bool   `yaml:"enabled" desc:"Turns message processing on or off without disabling the module."`
+	MaxLogs int    `yaml:"max_logs" validate:"gte=0,lte=1000" desc:"Log limit reported with every processed message." example:"100"`
 }
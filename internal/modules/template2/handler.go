@@ -3,6 +3,8 @@ package template2
 import (
 	"context"
 
+	"DiscordBotAgent/internal/core/featureflags"
+
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -32,7 +34,16 @@ func (h *Handler) OnMessageCreate(
 	}
 
 	event, ok := payload.(*discordgo.MessageCreate)
-	if !ok {
+	if !ok || event.Author == nil {
+		return
+	}
+
+	var roles []string
+	if event.Member != nil {
+		roles = event.Member.Roles
+	}
+	subject := featureflags.Subject{GuildID: event.GuildID, UserID: event.Author.ID, RoleIDs: roles}
+	if e := h.module.flags.Evaluate(ctx, FlagProcessMessages, subject); !e.Enabled && e.Reason != featureflags.ReasonUnknown {
 		return
 	}
 
@@ -5,17 +5,24 @@ import (
 
 	"DiscordBotAgent/internal/core/config_manager"
 	"DiscordBotAgent/internal/core/eventbus"
+	"DiscordBotAgent/internal/core/featureflags"
 	"DiscordBotAgent/internal/core/module_manager"
 	"DiscordBotAgent/internal/core/zap_logger"
 	"DiscordBotAgent/internal/modules/template"
 )
 
-const ModuleName = "template2"
+const (
+	ModuleName = "template2"
+
+	// FlagProcessMessages gates message processing per guild and user; an undefined flag processes every message.
+	FlagProcessMessages = "template2.process_messages"
+)
 
 type Module struct {
 	log           *zap_logger.Logger
 	eb            *eventbus.EventBus
 	mm            *module_manager.Manager
+	flags         *featureflags.Service
 	template      *template.Module
 	handler       *Handler
 	cfg           Config
@@ -26,12 +33,14 @@ func New(
 	log *zap_logger.Logger,
 	eb *eventbus.EventBus,
 	mm *module_manager.Manager,
+	flags *featureflags.Service,
 	template *template.Module,
 ) *Module {
 	m := &Module{
 		log:           log,
 		eb:            eb,
 		mm:            mm,
+		flags:         flags,
 		template:      template,
 		subscriptions: make([]eventbus.SubscriptionID, 0),
 	}
@@ -43,6 +52,12 @@ func (m *Module) Name() string {
 	return ModuleName
 }
 
+func (m *Module) DependsOn() []module_manager.Dependency {
+	return []module_manager.Dependency{
+		{Name: template.ModuleName, Version: "^1.0.0"},
+	}
+}
+
 func (m *Module) ConfigKey() string {
 	return config_manager.Contract.System.Discord.Template2
 }
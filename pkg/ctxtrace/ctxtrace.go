@@ -21,3 +21,21 @@ func Extract(ctx context.Context) string {
 	}
 	return ""
 }
+
+type actorCtxKey struct{}
+
+var actorKey = actorCtxKey{}
+
+func WithActor(
+	ctx context.Context,
+	actor string,
+) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+func ExtractActor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok {
+		return actor
+	}
+	return ""
+}